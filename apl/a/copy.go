@@ -0,0 +1,11 @@
+package a
+
+import "github.com/ktye/iv/apl"
+
+// deepcopy returns an independent copy of R.
+// Assignment already copies arrays, lists and dictionaries, but namespaces
+// and xgo values are references, also when they are nested in other values.
+// The deep copy duplicates them as well.
+func deepcopy(p *apl.Apl, _, R apl.Value) (apl.Value, error) {
+	return apl.DeepCopy(R), nil
+}
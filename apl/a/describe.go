@@ -0,0 +1,35 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/xgo"
+)
+
+// describe returns a dict with the columns name, kind and type.
+// R is the name of a package or an object.
+// For an xgo value, the go types of fields and the signatures of methods are returned.
+func describe(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
+	switch v := R.(type) {
+	case apl.String:
+		d, err := a.DescribePackage(string(v))
+		if err != nil {
+			return nil, fmt.Errorf("a i: %s", err)
+		}
+		return d, nil
+	case xgo.Value:
+		return v.Describe(), nil
+	case apl.Object:
+		keys := v.Keys()
+		names := make([]string, len(keys))
+		values := make([]apl.Value, len(keys))
+		for i, k := range keys {
+			names[i] = k.String(a.Format)
+			values[i] = v.At(k)
+		}
+		return apl.Describe(names, values), nil
+	default:
+		return nil, fmt.Errorf("a i: argument must be a package name or an object: %T", R)
+	}
+}
@@ -0,0 +1,17 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// freeze returns R wrapped as an immutable value.
+// Selective, indexed or modified assignment to a variable holding it fails.
+// The value is copied, see apl.Freeze.
+func freeze(p *apl.Apl, _, R apl.Value) (apl.Value, error) {
+	if _, ok := R.(apl.Function); ok {
+		return nil, fmt.Errorf("a f: cannot freeze a function")
+	}
+	return p.Freeze(R), nil
+}
@@ -0,0 +1,24 @@
+package a
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// namespace returns a new namespace.
+// If R is an object, e.g. a dict, the namespace is initialized with it's keys and values.
+// Otherwise R is ignored and the namespace is empty.
+func namespace(p *apl.Apl, _, R apl.Value) (apl.Value, error) {
+	ns := apl.NewNamespace()
+	obj, ok := R.(apl.Object)
+	if ok == false {
+		return ns, nil
+	}
+	for _, k := range obj.Keys() {
+		if err := ns.Set(k, obj.At(k)); err != nil {
+			return nil, fmt.Errorf("a n: %s", err)
+		}
+	}
+	return ns, nil
+}
@@ -4,8 +4,12 @@
 // This includes the parent application if the interpreter is built-in.
 //
 //	c 0 return number of CPUs
+//	d R    return a deep copy of R, including nested namespaces and xgo values
+//	f R    return R frozen, selective assignment into it fails
 //	g 0    return number of go routines
+//	i R    describe the package with name R, or the members of an object
 //	m 0    return runtime.MemStats as a dictionary
+//	n R    return a new namespace, initialized from the object R
 //	v 0    return go version
 package a
 
@@ -24,9 +28,13 @@ func Register(p *apl.Apl, name string) {
 	}
 	pkg := map[string]apl.Value{
 		"c": apl.ToFunction(cpus),
+		"d": apl.ToFunction(deepcopy),
+		"f": apl.ToFunction(freeze),
 		"g": apl.ToFunction(goroutines),
 		"h": apl.ToFunction(help),
+		"i": apl.ToFunction(describe),
 		"m": apl.ToFunction(Memstats),
+		"n": apl.ToFunction(namespace),
 		"p": apl.ToFunction(printvar),
 		"q": apl.ToFunction(quit),
 		"t": apl.ToFunction(timer),
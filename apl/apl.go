@@ -2,9 +2,12 @@
 package apl
 
 import (
+	"context"
 	"io"
 	"io/ioutil"
 	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/ktye/iv/apl/scan"
 )
@@ -12,7 +15,7 @@ import (
 // New starts a new interpreter.
 func New(w io.Writer) *Apl {
 	a := Apl{
-		stdout: w,
+		stdout: &output{w: w},
 		env:    newEnv(),
 		Origin: 1,
 		Format: Format{Fmt: make(map[reflect.Type]string)},
@@ -22,6 +25,9 @@ func New(w io.Writer) *Apl {
 		operators:  make(map[string][]Operator),
 		symbols:    make(map[rune]string),
 		pkg:        make(map[string]*env),
+		intr:       &interrupt{},
+		memo:       &memoTable{m: make(map[Function]*Memo)},
+		frozen:     &frozenSet{m: make(map[interface{}]Value)},
 	}
 	a.parser.a = &a
 	return &a
@@ -32,10 +38,11 @@ type Apl struct {
 	scan.Scanner
 	Format Format
 	parser
-	stdout io.Writer
+	stdout *output
 	stdimg ImageWriter
 	Tower  Tower
 	Origin int
+	CT     Number // Comparison tolerance, nil is the default 1E-14.
 	//PP         int
 	//Fmt        map[reflect.Type]string
 	env        *env
@@ -44,11 +51,45 @@ type Apl struct {
 	symbols    map[rune]string
 	pkg        map[string]*env
 	scaninit   bool
+	ctx        context.Context
+	intr       *interrupt
+	memo       *memoTable
+	frozen     *frozenSet
+}
+
+// Fork returns a copy of the interpreter, that can be used concurrently.
+// Variables of the current environment chain and of user packages are copied.
+// Registered primitives, operators and read-only packages are shared.
+func (a *Apl) Fork() *Apl {
+	b := *a
+	b.parser.a = &b
+	b.stdout = &output{w: a.stdout.writer()}
+	b.env = a.env.copy()
+	b.pkg = make(map[string]*env, len(a.pkg))
+	for name, e := range a.pkg {
+		if e.readonly == false {
+			e = e.copy()
+		}
+		b.pkg[name] = e
+	}
+	b.Format.Fmt = make(map[reflect.Type]string)
+	for t, s := range a.Format.Fmt {
+		b.Format.Fmt[t] = s
+	}
+	return &b
 }
 
 type Format struct {
-	PP  int
-	Fmt map[reflect.Type]string
+	PP     int
+	Fmt    map[reflect.Type]string
+	Elide  int    // If > 0, arrays with more elements print only head and tail.
+	Sep    string // Separator between elements, if not empty.
+	Eol    string // Row terminator, if not empty.
+	Dec    string // Decimal separator for floats, if not empty.
+	Group  string // Thousands separator for ints and floats, if not empty.
+	RatDec bool   // Rationals are printed as decimal approximations instead of fractions.
+
+	namespaces map[*Namespace]bool // Namespaces that are being printed, to detect cycles.
 }
 
 // LoadPkg loads a package from a file.
@@ -93,6 +134,29 @@ func (a *Apl) ParseAndEval(line string) error {
 	}
 }
 
+// EvalValue parses and evaluates the source and returns the value of the last expression.
+// The value is not written to the output, but the results of previous expressions are, as with Eval.
+// If the last expression is an assignment, the assigned value is returned.
+func (a *Apl) EvalValue(src string) (Value, error) {
+	p, err := a.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	defer a.intr.enter()()
+	return a.evalLast(p, true)
+}
+
+// EvalString parses and evaluates the source and returns the output as a string.
+// The output is captured only for this call, the writer given to New is restored afterwards.
+// On error, the output written so far is returned with the error.
+func (a *Apl) EvalString(src string) (string, error) {
+	var buf strings.Builder
+	save := a.stdout.swap(&buf)
+	defer a.stdout.swap(save)
+	err := a.ParseAndEval(src)
+	return buf.String(), err
+}
+
 func (a *Apl) Scan(line string) ([]scan.Token, error) {
 	// On the first call, the scanner needs to be told all symbols that
 	// have been registered.
@@ -108,14 +172,45 @@ func (a *Apl) Scan(line string) ([]scan.Token, error) {
 }
 
 func (a *Apl) SetOutput(w io.Writer) {
-	a.stdout = w
+	a.stdout.swap(w)
 }
 
 func (a *Apl) GetOutput() io.Writer {
-	if a.stdout == nil {
-		return ioutil.Discard
+	if w := a.stdout.writer(); w != nil {
+		return w
 	}
-	return a.stdout
+	return ioutil.Discard
+}
+
+// output is the writer of an interpreter.
+// Each fork has its own, such that EvalString can replace it,
+// while other forks are running.
+type output struct {
+	sync.Mutex
+	w io.Writer
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	o.Lock()
+	defer o.Unlock()
+	if o.w == nil {
+		return len(p), nil
+	}
+	return o.w.Write(p)
+}
+
+func (o *output) writer() io.Writer {
+	o.Lock()
+	defer o.Unlock()
+	return o.w
+}
+
+// swap sets the writer and returns the previous one.
+func (o *output) swap(w io.Writer) io.Writer {
+	o.Lock()
+	defer o.Unlock()
+	w, o.w = o.w, w
+	return w
 }
 
 func (a *Apl) SetImage(w ImageWriter) {
@@ -125,3 +220,18 @@ func (a *Apl) SetImage(w ImageWriter) {
 func newEnv() *env {
 	return &env{vars: map[string]Value{}}
 }
+
+// copy returns a copy of the environment chain and its variables.
+func (e *env) copy() *env {
+	if e == nil {
+		return nil
+	}
+	c := &env{parent: e.parent.copy(), vars: make(map[string]Value, len(e.vars))}
+	for k, v := range e.vars {
+		if v != nil {
+			v = v.Copy()
+		}
+		c.vars[k] = v
+	}
+	return c
+}
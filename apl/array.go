@@ -218,6 +218,14 @@ func (v MixedArray) Copy() Value {
 	return r
 }
 
+func (v MixedArray) DeepCopy(c Copies) Value {
+	r := MixedArray{Dims: CopyShape(v), Values: make([]Value, len(v.Values))}
+	for i := range v.Values {
+		r.Values[i] = c.Copy(v.Values[i])
+	}
+	return r
+}
+
 func (v MixedArray) At(i int) Value {
 	return v.Values[i]
 }
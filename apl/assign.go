@@ -25,8 +25,9 @@ func (as assignment) Eval(a *Apl) (Value, error) {
 // It contains the Identifier, it's indexes and a modification function.
 type Assignment struct {
 	Identifier  string
-	Identifiers []string // Multiple identifiers for vector assignment
-	Indexes     Value    // Should be convertible to an Index vector
+	Identifiers []string      // Multiple identifiers for vector assignment
+	Targets     []*Assignment // Nested targets for list assignment
+	Indexes     Value         // Should be convertible to an Index vector
 	Modifier    Function
 }
 
@@ -38,6 +39,12 @@ func (as *Assignment) Copy() Value {
 		r.Identifiers = make([]string, len(as.Identifiers))
 		copy(r.Identifiers, as.Identifiers)
 	}
+	if as.Targets != nil {
+		r.Targets = make([]*Assignment, len(as.Targets))
+		for i, t := range as.Targets {
+			r.Targets[i] = t.Copy().(*Assignment)
+		}
+	}
 	if as.Indexes != nil {
 		r.Indexes = as.Indexes.Copy()
 	}
@@ -58,10 +65,30 @@ func (as *Assignment) String(f Format) string {
 	id := as.Identifier
 	if as.Identifiers != nil {
 		id = strings.Join(as.Identifiers, " ")
+	} else if as.Targets != nil {
+		id = as.targetString()
 	}
 	return "assignment to " + id
 }
 
+// targetString formats nested targets in list notation.
+func (as *Assignment) targetString() string {
+	var b strings.Builder
+	b.WriteRune('(')
+	for _, t := range as.Targets {
+		if t.Identifiers != nil {
+			b.WriteString(strings.Join(t.Identifiers, " "))
+		} else if t.Targets != nil {
+			b.WriteString(t.targetString())
+		} else {
+			b.WriteString(t.Identifier)
+		}
+		b.WriteRune(';')
+	}
+	b.WriteRune(')')
+	return b.String()
+}
+
 // EvalAssign evalutes the left part of an assignment and
 // returns it as an Assignment value.
 // It handles indexed, selective and modified assignment.
@@ -94,6 +121,26 @@ func evalAssign(a *Apl, e expr, modifier Function) (Value, error) {
 		return &as, nil
 	}
 
+	// List assignment destructures nested values.
+	// Each target may be a numVar, a vector of numVars or another list:
+	//	((A B);C;)←(1 2;3;)
+	if le, ok := e.(list); ok {
+		as.Targets = make([]*Assignment, len(le))
+		for i, v := range le {
+			switch v.(type) {
+			case numVar, array, list:
+			default:
+				return nil, fmt.Errorf("list assignment can contain only numVars, vectors or lists: %T", v)
+			}
+			t, err := evalAssign(a, v, modifier)
+			if err != nil {
+				return nil, err
+			}
+			as.Targets[i] = t.(*Assignment)
+		}
+		return &as, nil
+	}
+
 	// The identifier is the right-most argument in the expression.
 	// The selection function (if present) is the function left to the Identifier.
 	selection := false
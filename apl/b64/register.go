@@ -0,0 +1,111 @@
+// Package b64 provides base64 encoding.
+//
+// Encode accepts a string scalar, a character vector or a vector of bytes
+// and returns a string scalar.
+// Decode returns the bytes as an integer vector, as the data may be binary.
+// Use s→frombytes to convert them to a string.
+// The optional left argument selects the alphabet: "std" (default) or "url".
+//
+//	b64→encode 'alpha'          ⍝ YWxwaGE=
+//	b64→encode 97 108 112 104 97 ⍝ YWxwaGE=
+//	"url" b64→decode "YWxwaGE="  ⍝ 97 108 112 104 97
+package b64
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the b64 package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "b64"
+	}
+	pkg := map[string]apl.Value{
+		"encode": apl.ToFunction(encode),
+		"decode": apl.ToFunction(decode),
+	}
+	a.RegisterPackage(name, pkg)
+}
+
+func encode(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	enc, err := encoding(L)
+	if err != nil {
+		return nil, fmt.Errorf("b64 encode: %s", err)
+	}
+	b, err := toBytes(R)
+	if err != nil {
+		return nil, fmt.Errorf("b64 encode: %s", err)
+	}
+	return apl.String(enc.EncodeToString(b)), nil
+}
+
+func decode(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	enc, err := encoding(L)
+	if err != nil {
+		return nil, fmt.Errorf("b64 decode: %s", err)
+	}
+	s, err := apl.JoinString(R)
+	if err != nil {
+		return nil, fmt.Errorf("b64 decode: %s", err)
+	}
+	b, err := enc.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("b64 decode: %s", err)
+	}
+	if len(b) == 0 {
+		return apl.EmptyArray{}, nil
+	}
+	v := apl.IntArray{Dims: []int{len(b)}, Ints: make([]int, len(b))}
+	for i := range b {
+		v.Ints[i] = int(b[i])
+	}
+	return v, nil
+}
+
+// toBytes returns the UTF-8 encoding of a string, or the bytes of an integer vector.
+func toBytes(R apl.Value) ([]byte, error) {
+	if s, err := apl.JoinString(R); err == nil {
+		return []byte(s), nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return nil, fmt.Errorf("argument must be a string or a byte vector: %T", R)
+	} else if n := len(ar.Shape()); n != 1 {
+		return nil, fmt.Errorf("argument must be a vector: rank %d", n)
+	}
+	b := make([]byte, ar.Size())
+	for i := range b {
+		n, ok := ar.At(i).(apl.Number)
+		if ok == false {
+			return nil, fmt.Errorf("argument must be a string or a byte vector: %T", ar.At(i))
+		}
+		c, ok := n.ToIndex()
+		if ok == false || c < 0 || c > 255 {
+			return nil, fmt.Errorf("value is not a byte: %s", ar.At(i).String(apl.Format{}))
+		}
+		b[i] = byte(c)
+	}
+	return b, nil
+}
+
+// encoding returns the base64 alphabet selected by L.
+func encoding(L apl.Value) (*base64.Encoding, error) {
+	if L == nil {
+		return base64.StdEncoding, nil
+	}
+	s, err := apl.JoinString(L)
+	if err != nil {
+		return nil, fmt.Errorf("left argument: %s", err)
+	}
+	switch s {
+	case "std":
+		return base64.StdEncoding, nil
+	case "url":
+		return base64.URLEncoding, nil
+	default:
+		return nil, fmt.Errorf("unknown alphabet: %s (use std or url)", s)
+	}
+}
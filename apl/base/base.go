@@ -0,0 +1,72 @@
+package base
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// to encodes R in base L with as many digits as needed for the largest value.
+// For a vector R, the digits are in the columns of the result, as with ⊤.
+// Zero has a single digit.
+func to(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	b, err := radix(a, "to", L)
+	if err != nil {
+		return nil, err
+	}
+	max := 0
+	if ar, ok := R.(apl.Array); ok {
+		for i := 0; i < ar.Size(); i++ {
+			n, err := natural(a, ar.At(i))
+			if err != nil {
+				return nil, err
+			}
+			if n > max {
+				max = n
+			}
+		}
+	} else if max, err = natural(a, R); err != nil {
+		return nil, err
+	}
+	m := 1
+	for n := max / b; n > 0; n /= b {
+		m++
+	}
+	v := make([]apl.Value, m)
+	for i := range v {
+		v[i] = L
+	}
+	return apl.Primitive("⊤").Call(a, apl.MixedArray{Dims: []int{m}, Values: v}, R)
+}
+
+// from decodes the digits R in base L.
+// For a matrix R, each column is decoded, as with ⊥.
+func from(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if _, err := radix(a, "from", L); err != nil {
+		return nil, err
+	}
+	return apl.Primitive("⊥").Call(a, L, R)
+}
+
+// radix returns the base L, which must be an integer larger than 1.
+func radix(a *apl.Apl, name string, L apl.Value) (int, error) {
+	if L == nil {
+		return 0, fmt.Errorf("%s: cannot be called monadically", name)
+	}
+	if n, ok := L.(apl.Number); ok {
+		if b, ok := n.ToIndex(); ok && b > 1 {
+			return b, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: base must be an integer larger than 1: %s", name, L.String(a.Format))
+}
+
+// natural returns v as a non-negative integer.
+func natural(a *apl.Apl, v apl.Value) (int, error) {
+	if n, ok := v.(apl.Number); ok {
+		if i, ok := n.ToIndex(); ok && i >= 0 {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("to: argument must be a non-negative integer: %s", v.String(a.Format))
+}
@@ -0,0 +1,28 @@
+// Package base converts integers to and from digit vectors of a single base.
+//
+// It wraps encode (⊤) and decode (⊥), without the need to construct a radix vector.
+//
+//	L base→to R        digits of the non-negative integers R in base L
+//	L base→from R      integers from the digits R in base L
+//
+// Examples:
+//
+//	16 base→to 255     15 15
+//	2 base→from 1 0 1  5
+package base
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the base package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "base"
+	}
+	pkg := map[string]apl.Value{
+		"to":   apl.ToFunction(to),
+		"from": apl.ToFunction(from),
+	}
+	a.RegisterPackage(name, pkg)
+}
@@ -39,7 +39,11 @@ func (c Complex) String(f apl.Format) string {
 	}
 	s := fmt.Sprintf(format, c.re, c.im)
 	if minus == false {
+		s = numbers.InfString(s)
 		s = strings.Replace(s, "-", "¯", -1)
+		if f.Dec != "" {
+			s = strings.Replace(s, ".", f.Dec, -1)
+		}
 	}
 	return s
 }
@@ -87,7 +91,11 @@ func (c Complex) ToIndex() (int, bool) {
 	return Float{c.re}.ToIndex()
 }
 
+// floatToComplex converts a Float to a Complex. NaN has no complex representation.
 func floatToComplex(f apl.Number) (apl.Number, bool) {
+	if f.(Float).IsNaN() {
+		return f, false
+	}
 	z := f.(Float).cpy()
 	return Complex{z, new(big.Float)}, true
 }
@@ -170,7 +178,10 @@ func (l Complex) Div2(R apl.Value) (apl.Value, bool) {
 
 	r := R.(Complex)
 	if r.re.Sign() == 0 && r.im.Sign() == 0 {
-		return numbers.Inf, true
+		if l.re.Sign() == 0 && l.im.Sign() == 0 {
+			return Float{}, true
+		}
+		return Float{new(big.Float).SetPrec(l.re.Prec()).SetInf(false)}, true
 	} else if l.re.Sign() == 0 && l.im.Sign() == 0 {
 		return l.cpy(), true // zero
 	}
@@ -11,17 +11,36 @@ import (
 	"github.com/ktye/iv/apl/numbers"
 )
 
+// Float is a big float of the precise tower.
+// A big.Float represents ±∞, but it has no NaN.
+// NaN is a Float with a nil *big.Float, it is the result of 0÷0 or ∞-∞.
 type Float struct {
 	*big.Float
 }
 
+// IsNaN returns if f is not a number.
+func (f Float) IsNaN() bool {
+	return f.Float == nil
+}
+
+// nan returns true, if any of the arguments is NaN.
+func nan(f Float, R apl.Value) bool {
+	return f.IsNaN() || R.(Float).IsNaN()
+}
+
 func (f Float) Copy() apl.Value {
+	if f.IsNaN() {
+		return f
+	}
 	re := new(big.Float)
 	re = re.Copy(f.Float)
 	return Float{re}
 }
 
 func (f Float) String(af apl.Format) string {
+	if f.IsNaN() {
+		return "NaN"
+	}
 	format, minus := getformat(af, f)
 	if format == "" {
 		if af.PP < 0 {
@@ -37,13 +56,23 @@ func (f Float) String(af apl.Format) string {
 		s = "0"
 	}
 	if minus == false {
+		s = numbers.InfString(s)
 		s = strings.Replace(s, "-", "¯", -1)
+		if af.Dec != "" {
+			s = strings.Replace(s, ".", af.Dec, -1)
+		}
+		s = apl.GroupDigits(af, s)
 	}
 	return s
 }
 
+// ParseFloat parses a big Float with the given precision.
+// The infinity literals ∞ and ¯∞ are accepted.
 func ParseFloat(s string, prec uint) (apl.Number, bool) {
 	s = strings.Replace(s, "¯", "-", -1)
+	if s == "∞" || s == "-∞" {
+		s = strings.Replace(s, "∞", "Inf", 1)
+	}
 	z, _, err := big.NewFloat(0).SetPrec(prec).Parse(s, 10)
 	if err != nil {
 		return nil, false
@@ -52,7 +81,7 @@ func ParseFloat(s string, prec uint) (apl.Number, bool) {
 }
 
 func (f Float) ToIndex() (int, bool) {
-	if f.IsInt() == false {
+	if f.IsNaN() || f.IsInt() == false {
 		return 0, false
 	}
 	i, _ := f.Float.Int64()
@@ -68,11 +97,18 @@ func (f Float) cpy() *big.Float {
 	return c.Copy(f.Float)
 }
 
+// Equals and Less are false, if any argument is NaN.
 func (f Float) Equals(R apl.Value) (apl.Bool, bool) {
+	if nan(f, R) {
+		return false, true
+	}
 	return f.Float.Cmp(R.(Float).Float) == 0, true
 }
 
 func (f Float) Less(R apl.Value) (apl.Bool, bool) {
+	if nan(f, R) {
+		return false, true
+	}
 	return f.Float.Cmp(R.(Float).Float) < 0, true
 }
 
@@ -80,76 +116,111 @@ func (f Float) Add() (apl.Value, bool) {
 	return f, true
 }
 func (f Float) Add2(R apl.Value) (apl.Value, bool) {
+	if nan(f, R) {
+		return Float{}, true
+	}
 	z := f.cpy()
-	return Float{z.Add(z, R.(Float).Float)}, true
+	return ieee(func() *big.Float { return z.Add(z, R.(Float).Float) })
 }
 
 func (f Float) Sub() (apl.Value, bool) {
+	if f.IsNaN() {
+		return f, true
+	}
 	z := f.cpy()
 	return Float{z.Neg(f.Float)}, true
 }
 func (f Float) Sub2(R apl.Value) (apl.Value, bool) {
+	if nan(f, R) {
+		return Float{}, true
+	}
 	z := f.cpy()
-	return Float{z.Sub(z, R.(Float).Float)}, true
+	return ieee(func() *big.Float { return z.Sub(z, R.(Float).Float) })
 }
 
 func (f Float) Mul() (apl.Value, bool) {
+	if f.IsNaN() {
+		return f, true
+	}
 	return apl.Int(f.Float.Sign()), true
 }
 func (f Float) Mul2(R apl.Value) (apl.Value, bool) {
+	if nan(f, R) {
+		return Float{}, true
+	}
 	z := f.cpy()
-	return Float{z.Mul(z, R.(Float).Float)}, true
+	return ieee(func() *big.Float { return z.Mul(z, R.(Float).Float) })
 }
 
 func (f Float) Div() (apl.Value, bool) {
-	return Float{big.NewFloat(1)}.Div2(f)
+	if f.IsNaN() {
+		return f, true
+	}
+	return Float{f.cpy().SetInt64(1)}.Div2(f)
 }
 func (f Float) Div2(R apl.Value) (apl.Value, bool) {
-	if f.Float.IsInf() {
-		return numbers.Inf, true
-	}
-	if R.(Float).Float.IsInf() {
-		return numbers.NaN, true
+	if nan(f, R) {
+		return Float{}, true
 	}
-	lz := f.Float.Sign() == 0
-	rz := R.(Float).Float.Sign() == 0
-	if lz && rz {
-		return numbers.NaN, true
-	} else if lz {
-		z := f.cpy().SetInt64(0)
-		return Float{z}, true
-	} else if rz {
-		return numbers.Inf, true
-	}
-	return Float{f.cpy().Quo(f.Float, R.(Float).Float)}, true
+	z := f.cpy()
+	return ieee(func() *big.Float { return z.Quo(z, R.(Float).Float) })
+}
+
+// ieee returns the result of a big float operation with IEEE semantics.
+// A big float cannot be NaN: if the operation panics with big.ErrNaN,
+// as for 0÷0 or ∞-∞, it returns a NaN Float.
+func ieee(op func() *big.Float) (v apl.Value, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isnan := r.(big.ErrNaN); isnan == false {
+				panic(r)
+			}
+			v, ok = Float{}, true
+		}
+	}()
+	return Float{op()}, true
 }
 
 func (f Float) Pow() (apl.Value, bool) {
-	z := bigfloat.Exp(f.Float)
-	if z.IsInf() {
-		return numbers.Inf, true
+	if f.IsNaN() {
+		return f, true
+	} else if f.Float.IsInf() {
+		if f.Float.Sign() < 0 {
+			return Float{f.cpy().SetInt64(0)}, true
+		}
+		return f, true
 	}
-	return Float{z}, true
+	return Float{bigfloat.Exp(f.Float)}, true
 }
 func (f Float) Pow2(R apl.Value) (apl.Value, bool) {
-	if f.Float.Cmp(f.Float) < 0 {
+	if nan(f, R) {
+		return Float{}, true
+	} else if f.Float.Cmp(f.Float) < 0 {
 		return nil, false
 	}
-	z := bigfloat.Pow(f.Float, R.(Float).Float)
-	if z.IsInf() {
-		return numbers.Inf, true
+	if r := R.(Float).Float; f.Float.IsInf() || r.IsInf() {
+		x, _ := f.Float.Float64()
+		y, _ := r.Float64()
+		if z := math.Pow(x, y); math.IsNaN(z) == false {
+			return Float{f.cpy().SetFloat64(z)}, true
+		}
+		return Float{}, true
 	}
-	return Float{z}, true
+	return Float{bigfloat.Pow(f.Float, R.(Float).Float)}, true
 }
 
 func (f Float) Log() (apl.Value, bool) {
-	if f.Float.Sign() < 0 {
+	if f.IsNaN() {
+		return f, true
+	} else if f.Float.Sign() < 0 {
 		return nil, false
 	}
 	return Float{bigfloat.Log(f.Float)}, true
 }
 func (f Float) Log2(R apl.Value) (apl.Value, bool) {
-	if f.Float.Sign() < 0 {
+	if nan(f, R) {
+		return Float{}, true
+	} else if f.Float.Sign() < 0 {
 		return nil, false
 	}
 	r := R.(Float).Float
@@ -162,21 +233,61 @@ func (f Float) Log2(R apl.Value) (apl.Value, bool) {
 }
 
 func (f Float) Abs() (apl.Value, bool) {
-	if f.Float.Sign() < 0 {
+	if f.IsNaN() {
+		return f, true
+	} else if f.Float.Sign() < 0 {
 		return f.Sub()
 	}
 	return f, true
 }
 
 func (f Float) Ceil() (apl.Value, bool) {
+	if f.IsNaN() {
+		return f, true
+	}
 	z, _ := f.Float.Float64()
 	return Float{f.cpy().SetFloat64(math.Ceil(z))}, true
 }
 func (f Float) Floor() (apl.Value, bool) {
+	if f.IsNaN() {
+		return f, true
+	}
 	z, _ := f.Float.Float64()
 	return Float{f.cpy().SetFloat64(math.Floor(z))}, true
 }
 
+// PiTimes multiplies with π, which is computed with the precision of f.
+func (f Float) PiTimes() (apl.Value, bool) {
+	if f.IsNaN() {
+		return f, true
+	}
+	z := pi(f.Prec())
+	return Float{z.Mul(z, f.Float)}, true
+}
+
+// pi computes π to the given precision with the Gauss-Legendre algorithm.
+func pi(prec uint) *big.Float {
+	p := prec + 64
+	num := func(x float64) *big.Float { return new(big.Float).SetPrec(p).SetFloat64(x) }
+	a, t, s := num(1), num(0.25), num(1)
+	b := num(0.5)
+	b.Sqrt(b)
+	for i := uint(1); i < 2*p; i *= 2 {
+		an := num(0).Add(a, b)
+		an.Quo(an, num(2))
+		b.Sqrt(b.Mul(a, b))
+		d := num(0).Sub(a, an)
+		d.Mul(d, d)
+		t.Sub(t, d.Mul(d, s))
+		s.Mul(s, num(2))
+		a = an
+	}
+	z := num(0).Add(a, b)
+	z.Mul(z, z)
+	z.Quo(z, t.Mul(t, num(4)))
+	return z.SetPrec(prec)
+}
+
 // TODO Trig
 
 // TODO Gcd
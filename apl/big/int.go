@@ -2,6 +2,7 @@ package big
 
 import (
 	"math/big"
+	"math/bits"
 	"strings"
 
 	"github.com/ktye/iv/apl"
@@ -18,7 +19,7 @@ type Int struct {
 
 func (i Int) String(f apl.Format) string {
 	// TODO formats
-	s := i.Int.String()
+	s := apl.GroupDigits(f, i.Int.String())
 	if s[0] == '-' {
 		return "¯" + s[1:]
 	}
@@ -120,6 +121,8 @@ func (i Int) Div2(R apl.Value) (apl.Value, bool) {
 		return numbers.NaN, true
 	} else if L0 {
 		return Int{big.NewInt(0)}, true
+	} else if R0 && i.Int.Sign() < 0 {
+		return numbers.NegInf, true
 	} else if R0 {
 		return numbers.Inf, true
 	}
@@ -185,3 +188,19 @@ func (i Int) Gamma() (apl.Value, bool) {
 func (L Int) Gcd(R apl.Value) (apl.Value, bool) {
 	return Int{big.NewInt(0).GCD(nil, nil, L.Int, R.(Int).Int)}, true
 }
+
+// PopCount returns the number of set bits.
+// Integers that fit into 64 bits are counted in two's complement.
+// Larger integers must not be negative.
+func (i Int) PopCount() (int, bool) {
+	if i.Int.IsInt64() {
+		return bits.OnesCount64(uint64(i.Int.Int64())), true
+	} else if i.Int.Sign() < 0 {
+		return 0, false
+	}
+	n := 0
+	for _, w := range i.Int.Bits() {
+		n += bits.OnesCount(uint(w))
+	}
+	return n, true
+}
@@ -2,7 +2,6 @@ package big
 
 import (
 	"fmt"
-	"math"
 	"math/big"
 	"strings"
 
@@ -22,15 +21,21 @@ func (r Rat) Copy() apl.Value {
 	t = t.Set(r.Rat)
 	return Rat{t}
 }
+
+// String formats a Rat as an exact fraction 3r4, or an integer if the denominator is 1.
+// If f.RatDec is set, it is formatted as a decimal approximation like a Float.
 func (r Rat) String(f apl.Format) string {
 	format, minus := getformat(f, r)
-	if format == "" {
+	if format == "" && f.RatDec {
+		n, _ := r.Rat.Float64()
+		return numbers.Float(n).String(f)
+	} else if format == "" {
 		s := r.Rat.String()
 		if minus == false {
 			s = strings.Replace(s, "-", "¯", -1)
 		}
 		if strings.HasSuffix(s, "/1") {
-			return s[:len(s)-2]
+			return apl.GroupDigits(f, s[:len(s)-2])
 		}
 		return strings.Replace(s, "/", "r", 1)
 	} else {
@@ -38,6 +43,10 @@ func (r Rat) String(f apl.Format) string {
 		s := fmt.Sprintf(format, n)
 		if minus == false {
 			s = strings.Replace(s, "-", "¯", -1)
+			if f.Dec != "" {
+				s = strings.Replace(s, ".", f.Dec, -1)
+			}
+			s = apl.GroupDigits(f, s)
 		}
 		return s
 	}
@@ -51,6 +60,11 @@ func (r Rat) ToIndex() (int, bool) {
 	return Int{i}.ToIndex()
 }
 
+func ratToFloat(n apl.Number) (apl.Number, bool) {
+	f, _ := n.(Rat).Rat.Float64()
+	return numbers.Float(f), true
+}
+
 func ParseRat(s string) (apl.Number, bool) {
 	s = strings.Replace(s, "¯", "-", -1)
 	s = strings.Replace(s, "r", "/", 1)
@@ -62,6 +76,51 @@ func ParseRat(s string) (apl.Number, bool) {
 	return Rat{r}, true
 }
 
+// limitDenominator returns the closest rational to x with a denominator not larger than max.
+// It uses the convergents of the continued fraction expansion of x.
+func limitDenominator(x *big.Rat, max *big.Int) *big.Rat {
+	if x.Denom().Cmp(max) <= 0 {
+		return new(big.Rat).Set(x)
+	}
+	neg := x.Sign() < 0
+	n := new(big.Int).Abs(x.Num())
+	d := new(big.Int).Set(x.Denom())
+	p0, q0, p1, q1 := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	for {
+		a, m := new(big.Int).QuoRem(n, d, new(big.Int))
+		q2 := new(big.Int).Mul(a, q1)
+		q2.Add(q2, q0)
+		if q2.Cmp(max) > 0 {
+			break
+		}
+		p2 := new(big.Int).Mul(a, p1)
+		p2.Add(p2, p0)
+		p0, q0, p1, q1 = p1, q1, p2, q2
+		n, d = d, m
+	}
+
+	// The best approximation is either the last convergent p1/q1
+	// or the semiconvergent with the largest allowed denominator.
+	k := new(big.Int).Sub(max, q0)
+	k.Quo(k, q1)
+	sp := new(big.Int).Mul(k, p1)
+	sq := new(big.Int).Mul(k, q1)
+	semi := new(big.Rat).SetFrac(sp.Add(sp, p0), sq.Add(sq, q0))
+	conv := new(big.Rat).SetFrac(p1, q1)
+
+	ax := new(big.Rat).Abs(x)
+	ds := new(big.Rat).Sub(semi, ax)
+	dc := new(big.Rat).Sub(conv, ax)
+	z := conv
+	if ds.Abs(ds).Cmp(dc.Abs(dc)) < 0 {
+		z = semi
+	}
+	if neg {
+		z.Neg(z)
+	}
+	return z
+}
+
 func (l Rat) Equals(R apl.Value) (apl.Bool, bool) {
 	return l.Rat.Cmp(R.(Rat).Rat) == 0, true
 }
@@ -120,6 +179,8 @@ func (l Rat) Div2(R apl.Value) (apl.Value, bool) {
 		return numbers.NaN, true
 	} else if L0 {
 		return Int{big.NewInt(0)}, true
+	} else if R0 && l.Rat.Sign() < 0 {
+		return numbers.NegInf, true
 	} else if R0 {
 		return numbers.Inf, true
 	}
@@ -174,15 +235,20 @@ func (r Rat) Abs() (apl.Value, bool) {
 	return r, true
 }
 
+// Ceil and Floor are exact and return an Int.
 func (r Rat) Ceil() (apl.Value, bool) {
-	f, _ := r.Rat.Float64()
-	return Rat{new(big.Rat).SetFloat64(math.Ceil(f))}, true
+	z := new(big.Int).Neg(r.Rat.Num())
+	z.Div(z, r.Rat.Denom())
+	return Int{z.Neg(z)}, true
 }
 func (r Rat) Floor() (apl.Value, bool) {
-	f, _ := r.Rat.Float64()
-	return Rat{new(big.Rat).SetFloat64(math.Floor(f))}, true
+	// Div rounds to -∞, as the denominator is positive.
+	return Int{new(big.Int).Div(r.Rat.Num(), r.Rat.Denom())}, true
 }
 
+// Gcd returns the greatest common divisor of two rationals exactly:
+//	gcd(a/b, c/d) = gcd(a×d, c×b) / b×d
+// For integers, it is the same as the integer gcd.
 func (L Rat) Gcd(R apl.Value) (apl.Value, bool) {
 	ab := L.Rat
 	cd := R.(Rat).Rat
@@ -197,3 +263,20 @@ func (L Rat) Gcd(R apl.Value) (apl.Value, bool) {
 	rat := big.NewRat(1, 1).SetFrac(gcd, bd)
 	return Rat{rat}, true
 }
+
+// Lcm returns the least common multiple of two rationals exactly:
+//	lcm(a/b, c/d) = lcm(a×d, c×b) / b×d
+// The result is not negative.
+// Zero arguments are handled by the caller, the gcd is never zero.
+func (L Rat) Lcm(R apl.Value) (apl.Value, bool) {
+	ab := L.Rat
+	cd := R.(Rat).Rat
+	ad := big.NewInt(0).Mul(ab.Num(), cd.Denom())
+	cb := big.NewInt(0).Mul(cd.Num(), ab.Denom())
+	gcd := big.NewInt(0).GCD(nil, nil, ad, cb)
+	lcm := big.NewInt(0).Mul(ad, cb)
+	lcm.Abs(lcm)
+	lcm.Quo(lcm, gcd)
+	bd := big.NewInt(0).Mul(ab.Denom(), cd.Denom())
+	return Rat{big.NewRat(1, 1).SetFrac(lcm, bd)}, true
+}
@@ -2,16 +2,41 @@ package big
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
+	"strings"
 
 	"github.com/ktye/iv/apl"
 	"github.com/ktye/iv/apl/numbers"
 )
 
+// Register adds the package big, which switches the numeric tower at runtime:
+//	big→set 0            ⍝ default tower: Float->Complex
+//	big→set 1            ⍝ big integers and rationals: Int->Rat
+//	big→set 256          ⍝ precise floats with 256 bits: Float->Complex
+//	big→set `normal      ⍝ same as big→set 0
+//	big→set `big         ⍝ same as big→set 1
+//	big→set `exact       ⍝ default tower with exact division, see SetExact
+//	128 big→set `precise ⍝ precise floats with 128 bits (default 256)
+// The precision of the precise tower is also set or queried with ⎕FPC.
+// Arithmetic uses the precision of the left argument, which can be set per value:
+//	X←512 big→float 1    ⍝ big float with 512 bits
+//	big→prec X÷3         ⍝ 512
+//	big→float "0.1"      ⍝ parse with the current precision
+// Floats are converted to rationals exactly or with a bound for the denominator:
+//	big→rat 0.5          ⍝ 1r2
+//	big→rat 0.1          ⍝ 3602879701896397r36028797018963968
+//	10 big→rat 0.333333  ⍝ 1r3
+// Existing variables are not converted.
+// They keep the number types of the previous tower and cannot be mixed
+// with numbers of the new tower. Assign them again to convert them.
 func Register(a *apl.Apl, name string) {
 	pkg := map[string]apl.Value{
-		"set": apl.ToFunction(settower),
+		"set":   apl.ToFunction(settower),
+		"float": apl.ToFunction(tofloat),
+		"prec":  apl.ToFunction(precision),
+		"rat":   apl.ToFunction(torat),
 	}
 	if name == "" {
 		name = "big"
@@ -52,6 +77,62 @@ func SetBigTower(a *apl.Apl) {
 	}
 }
 
+// SetExact switches between exact and float division.
+// If exact is false, the default tower Float->Complex->Time is set, as with numbers.Register.
+// Otherwise the default tower is extended by big integers and rationals: Int->Rat->Float->Complex->Time.
+// Integers that do not divide evenly result in a rational instead of a float,
+// e.g. 1÷3 is 1r3. Operations that are not defined for rationals
+// are computed with floats.
+// Only literals with an r (1r3) are parsed as rationals, 1.5 is still a float.
+func SetExact(a *apl.Apl, exact bool) {
+	numbers.Register(a)
+	if exact == false {
+		return
+	}
+	m := make(map[reflect.Type]*apl.Numeric)
+	m[reflect.TypeOf(Int{})] = &apl.Numeric{
+		Class:  0,
+		Parse:  ParseInt,
+		Uptype: intToRat,
+	}
+	m[reflect.TypeOf(Rat{})] = &apl.Numeric{
+		Class: 1,
+		Parse: func(s string) (apl.Number, bool) {
+			if strings.Index(s, "r") == -1 {
+				return nil, false
+			}
+			return ParseRat(s)
+		},
+		Uptype: ratToFloat,
+	}
+	for t, num := range a.Tower.Numbers {
+		m[t] = &apl.Numeric{
+			Class:  num.Class + 2,
+			Parse:  num.Parse,
+			Uptype: num.Uptype,
+		}
+	}
+	t := apl.Tower{
+		Numbers: m,
+		Import: func(n apl.Number) apl.Number {
+			if b, ok := n.(apl.Bool); ok {
+				if b {
+					return Int{big.NewInt(1)}
+				}
+				return Int{big.NewInt(0)}
+			} else if n, ok := n.(apl.Int); ok {
+				return Int{big.NewInt(int64(n))}
+			}
+			return n
+		},
+		Uniform: a.Tower.Uniform,
+		Prec:    a.Tower.Prec,
+	}
+	if err := a.SetTower(t); err != nil {
+		panic(err)
+	}
+}
+
 // SetPreciseTower sets the numerical tower to Float->Complex with the given precision.
 func SetPreciseTower(a *apl.Apl, prec uint) {
 	m := make(map[reflect.Type]*apl.Numeric)
@@ -80,6 +161,8 @@ func SetPreciseTower(a *apl.Apl, prec uint) {
 			return n
 		},
 		Uniform: func(v []apl.Value) (apl.Value, bool) { return nil, false },
+		Prec:    prec,
+		SetPrec: SetPreciseTower,
 	}
 	if err := a.SetTower(t); err != nil {
 		panic(err)
@@ -87,9 +170,15 @@ func SetPreciseTower(a *apl.Apl, prec uint) {
 }
 
 func settower(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if name, ok := R.(apl.String); ok {
+		return R, setnamed(a, L, string(name))
+	}
+	if L != nil {
+		return nil, fmt.Errorf("set: left argument is only allowed for `precise")
+	}
 	n, ok := R.(apl.Number)
 	if ok == false {
-		return nil, fmt.Errorf("set needs a number (0, 1, 256...)")
+		return nil, fmt.Errorf("set needs a number (0, 1, 256...) or a name")
 	}
 	idx, ok := n.ToIndex()
 	if ok == false {
@@ -107,6 +196,142 @@ func settower(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 	return R, nil
 }
 
+func setnamed(a *apl.Apl, L apl.Value, name string) error {
+	if L != nil && name != "precise" {
+		return fmt.Errorf("set: left argument is only allowed for `precise")
+	}
+	switch name {
+	case "normal":
+		numbers.Register(a)
+	case "big":
+		SetBigTower(a)
+	case "exact":
+		SetExact(a, true)
+	case "precise":
+		prec := 256
+		if L != nil {
+			n, ok := L.(apl.Number)
+			if ok == false {
+				return fmt.Errorf("set: precision must be a number")
+			}
+			if prec, ok = n.ToIndex(); ok == false || prec < 2 {
+				return fmt.Errorf("set: precision must be an integer > 1")
+			}
+		}
+		SetPreciseTower(a, uint(prec))
+	default:
+		return fmt.Errorf("set: unknown tower: %s", name)
+	}
+	return nil
+}
+
+// tofloat converts R to a big float with the precision given by L.
+// R may be a number or a string, which is parsed.
+// Without L, the precision of the tower is used, or 256 bits for fixed towers.
+func tofloat(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	prec := a.Tower.Prec
+	if a.Tower.SetPrec == nil {
+		prec = 256
+	}
+	if L != nil {
+		n, ok := L.(apl.Number)
+		if ok == false {
+			return nil, fmt.Errorf("float: precision must be a number")
+		}
+		i, ok := n.ToIndex()
+		if ok == false || i < 2 {
+			return nil, fmt.Errorf("float: precision must be an integer > 1")
+		}
+		prec = uint(i)
+	}
+	z := new(big.Float).SetPrec(prec)
+	switch v := R.(type) {
+	case apl.String:
+		f, ok := ParseFloat(string(v), prec)
+		if ok == false {
+			return nil, fmt.Errorf("float: cannot parse %q", string(v))
+		}
+		return f, nil
+	case apl.Bool:
+		if v {
+			z.SetInt64(1)
+		}
+	case apl.Int:
+		z.SetInt64(int64(v))
+	case numbers.Float:
+		z.SetFloat64(float64(v))
+	case Int:
+		z.SetInt(v.Int)
+	case Rat:
+		z.SetRat(v.Rat)
+	case Float:
+		if v.IsNaN() {
+			return v, nil
+		}
+		z.Set(v.Float)
+	default:
+		return nil, fmt.Errorf("float: cannot convert %T", R)
+	}
+	return Float{z}, nil
+}
+
+// torat converts R to a rational.
+// Without L, the conversion is exact: the rational has the same value as the IEEE float.
+// Otherwise the closest rational with a denominator not larger than L is returned.
+func torat(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	r := new(big.Rat)
+	switch v := R.(type) {
+	case apl.Bool:
+		if v {
+			r.SetInt64(1)
+		}
+	case apl.Int:
+		r.SetInt64(int64(v))
+	case numbers.Float:
+		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+			return nil, fmt.Errorf("rat: number is not finite: %s", v.String(a.Format))
+		}
+		r.SetFloat64(float64(v))
+	case Int:
+		r.SetInt(v.Int)
+	case Rat:
+		r.Set(v.Rat)
+	case Float:
+		if v.IsNaN() || v.IsInf() {
+			return nil, fmt.Errorf("rat: number is not finite")
+		}
+		v.Float.Rat(r)
+	default:
+		return nil, fmt.Errorf("rat: cannot convert %T", R)
+	}
+	if L == nil {
+		return Rat{r}, nil
+	}
+	n, ok := L.(apl.Number)
+	if ok == false {
+		return nil, fmt.Errorf("rat: denominator bound must be a number")
+	}
+	max, ok := n.ToIndex()
+	if ok == false || max < 1 {
+		return nil, fmt.Errorf("rat: denominator bound must be a positive integer")
+	}
+	return Rat{limitDenominator(r, big.NewInt(int64(max)))}, nil
+}
+
+// precision returns the precision in bits of a big float.
+func precision(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("prec: cannot be called dyadically")
+	}
+	switch v := R.(type) {
+	case Float:
+		return apl.Int(v.Prec()), nil
+	case Complex:
+		return apl.Int(v.re.Prec()), nil
+	}
+	return nil, fmt.Errorf("prec: argument is not a big float: %T", R)
+}
+
 func getformat(f apl.Format, num apl.Value) (string, bool) {
 	if f.Fmt == nil {
 		return "", false
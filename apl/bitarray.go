@@ -0,0 +1,137 @@
+package apl
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// BitThreshold is the minimum size of a boolean array
+// that is stored as a BitArray by Unify and the numeric towers.
+// Smaller boolean arrays use a BoolArray.
+var BitThreshold = 1 << 12
+
+// BitArray is a uniform array of booleans packed into 64 bit words.
+// It behaves like a BoolArray, but uses one bit per element.
+type BitArray struct {
+	Dims []int
+	Bits []uint64
+}
+
+// NewBitArray returns a BitArray of the given shape with all bits cleared.
+func NewBitArray(shape []int) BitArray {
+	return BitArray{Dims: shape, Bits: make([]uint64, (Prod(shape)+63)/64)}
+}
+
+// MakeBoolArray returns a BitArray if the size is at least BitThreshold
+// and a BoolArray otherwise.
+func MakeBoolArray(shape []int, b []bool) Uniform {
+	if len(b) < BitThreshold {
+		return BoolArray{Dims: shape, Bools: b}
+	}
+	r := NewBitArray(shape)
+	for i, v := range b {
+		if v {
+			r.Bits[i>>6] |= 1 << uint(i&63)
+		}
+	}
+	return r
+}
+
+func (b BitArray) String(f Format) string {
+	return ArrayString(f, b)
+}
+
+func (b BitArray) Copy() Value {
+	r := BitArray{Dims: CopyShape(b), Bits: make([]uint64, len(b.Bits))}
+	copy(r.Bits, b.Bits)
+	return r
+}
+
+func (b BitArray) At(i int) Value {
+	return Bool(b.Bits[i>>6]&(1<<uint(i&63)) != 0)
+}
+
+func (b BitArray) Shape() []int {
+	return b.Dims
+}
+
+func (b BitArray) Size() int {
+	return Prod(b.Dims)
+}
+
+func (b BitArray) Zero() Value {
+	return Bool(false)
+}
+
+func (b BitArray) Set(i int, v Value) error {
+	if i < 0 || i >= b.Size() {
+		return fmt.Errorf("index out of range")
+	}
+	c, ok := v.(Bool)
+	if ok == false {
+		return fmt.Errorf("cannot assign %T to BitArray", v)
+	}
+	if c {
+		b.Bits[i>>6] |= 1 << uint(i&63)
+	} else {
+		b.Bits[i>>6] &^= 1 << uint(i&63)
+	}
+	return nil
+}
+
+func (b BitArray) Make(shape []int) Uniform {
+	return NewBitArray(shape)
+}
+
+func (b BitArray) Reshape(shape []int) Value {
+	res := NewBitArray(shape)
+	n := b.Size()
+	if n == 0 {
+		return res
+	}
+	size := res.Size()
+	if n%64 == 0 {
+		// Whole words can be repeated.
+		for i := range res.Bits {
+			res.Bits[i] = b.Bits[i%len(b.Bits)]
+		}
+		res.clearTail(size)
+		return res
+	}
+	k := 0
+	for i := 0; i < size; i++ {
+		if b.Bits[k>>6]&(1<<uint(k&63)) != 0 {
+			res.Bits[i>>6] |= 1 << uint(i&63)
+		}
+		k++
+		if k == n {
+			k = 0
+		}
+	}
+	return res
+}
+
+// clearTail clears the unused bits in the last word.
+func (b BitArray) clearTail(size int) {
+	if r := uint(size & 63); r != 0 && len(b.Bits) > 0 {
+		b.Bits[len(b.Bits)-1] &= 1<<r - 1
+	}
+}
+
+// Count returns the number of true elements.
+func (b BitArray) Count() int {
+	n := 0
+	for _, w := range b.Bits {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Bools converts the BitArray to a BoolArray.
+func (b BitArray) Bools() BoolArray {
+	r := BoolArray{Dims: CopyShape(b), Bools: make([]bool, b.Size())}
+	for i := range r.Bools {
+		r.Bools[i] = b.Bits[i>>6]&(1<<uint(i&63)) != 0
+	}
+	return r
+}
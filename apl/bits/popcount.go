@@ -0,0 +1,58 @@
+package bits
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// popcounter is implemented by the integer types of the numeric towers.
+type popcounter interface {
+	PopCount() (int, bool)
+}
+
+// popcount returns the number of set bits of each integer in R.
+func popcount(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("popcount: cannot be called dyadically")
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		n, err := count(a, R)
+		if err != nil {
+			return nil, err
+		}
+		return apl.Int(n), nil
+	}
+	res := apl.IntArray{Dims: apl.CopyShape(ar), Ints: make([]int, ar.Size())}
+	for i := range res.Ints {
+		n, err := count(a, ar.At(i))
+		if err != nil {
+			return nil, err
+		}
+		res.Ints[i] = n
+	}
+	return res, nil
+}
+
+func count(a *apl.Apl, v apl.Value) (int, error) {
+	if b, ok := v.(apl.Bool); ok {
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if p, ok := v.(popcounter); ok {
+		if n, ok := p.PopCount(); ok {
+			return n, nil
+		}
+	}
+	// Other numbers, such as floats, count if they are integral.
+	if n, ok := v.(apl.Number); ok {
+		if i, ok := n.ToIndex(); ok {
+			c, _ := apl.Int(i).PopCount()
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("popcount: argument must be an integer: %s", v.String(a.Format))
+}
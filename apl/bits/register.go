@@ -0,0 +1,23 @@
+// Package bits provides bit counting functions for integers.
+//
+//	bits→popcount R    number of set bits, element-wise
+//
+// Negative integers are counted in their 64 bit two's complement representation:
+//
+//	bits→popcount ¯1   64
+package bits
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the bits package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "bits"
+	}
+	pkg := map[string]apl.Value{
+		"popcount": apl.ToFunction(popcount),
+	}
+	a.RegisterPackage(name, pkg)
+}
@@ -110,7 +110,7 @@ func (R Channel) Apply(a *Apl, f Function, L Value, filter bool) Channel {
 				}
 				v, err = f.Call(a, lv, v)
 				if err != nil {
-					c[0] <- Error{err}
+					c[0] <- Error{E: err}
 					close(r[1])
 					return
 				}
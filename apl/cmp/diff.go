@@ -0,0 +1,128 @@
+package cmp
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// diff compares the elements of L and R with match (≡) and returns a dict
+// with the keys index, left and right for all elements that differ:
+//
+//	1 2 3 4 cmp→diff 1 2.5 3 5
+//	index left right
+//	2     2    2.5
+//	4     4    5
+//
+// Indexes depend on ⎕IO. For arrays of higher rank, each index is a vector.
+// Scalars are compared directly, the index is empty.
+// If the shapes differ, elements are not compared and the dict
+// has a single key shape with both shapes: shape: (3;4;)
+func diff(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("diff: cannot be called monadically")
+	}
+	ls, rs := shape(L), shape(R)
+	if equalShape(ls, rs) == false {
+		return dict([]string{"shape"}, []apl.Value{apl.List{intVector(ls), intVector(rs)}}), nil
+	}
+
+	match := apl.Primitive("≡")
+	var idx, left, right []apl.Value
+	n := 1
+	al, isarray := L.(apl.Array)
+	if isarray {
+		n = al.Size()
+	}
+	ar, _ := R.(apl.Array)
+	pos := make([]int, len(ls))
+	for i := 0; i < n; i++ {
+		l, r := L, R
+		if isarray {
+			l, r = al.At(i), ar.At(i)
+		}
+		m, err := match.Call(a, l, r)
+		if err != nil {
+			return nil, fmt.Errorf("diff: %s", err)
+		}
+		if m == apl.Bool(false) {
+			idx = append(idx, index(a, pos))
+			left = append(left, l.Copy())
+			right = append(right, r.Copy())
+		}
+		apl.IncArrayIndex(pos, ls)
+	}
+	iv := vector(a, idx)
+	if isarray == false {
+		iv = apl.EmptyArray{} // Scalars have no index.
+	}
+	return dict([]string{"index", "left", "right"}, []apl.Value{
+		iv, vector(a, left), vector(a, right),
+	}), nil
+}
+
+// shape returns the shape of v, which is empty for a scalar.
+// An empty array has the shape 0.
+func shape(v apl.Value) []int {
+	if ar, ok := v.(apl.Array); ok {
+		if s := ar.Shape(); len(s) > 0 {
+			return s
+		}
+		return []int{0}
+	}
+	return nil
+}
+
+func equalShape(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// index returns the ⎕IO based index of an element.
+// For vectors it is a number, otherwise a vector.
+func index(a *apl.Apl, pos []int) apl.Value {
+	if len(pos) == 1 {
+		return apl.Int(pos[0] + a.Origin)
+	}
+	v := make([]int, len(pos))
+	for i := range v {
+		v[i] = pos[i] + a.Origin
+	}
+	return intVector(v)
+}
+
+func intVector(v []int) apl.Value {
+	if len(v) == 0 {
+		return apl.EmptyArray{}
+	}
+	return apl.IntArray{Dims: []int{len(v)}, Ints: append([]int{}, v...)}
+}
+
+// vector returns the values as a uniform vector, or a list if they are arrays.
+func vector(a *apl.Apl, v []apl.Value) apl.Value {
+	if len(v) == 0 {
+		return apl.EmptyArray{}
+	}
+	for _, e := range v {
+		if _, ok := e.(apl.Array); ok {
+			return apl.List(v)
+		}
+	}
+	return a.UnifyArray(apl.MixedArray{Dims: []int{len(v)}, Values: v})
+}
+
+func dict(keys []string, values []apl.Value) *apl.Dict {
+	d := apl.Dict{K: make([]apl.Value, len(keys)), M: make(map[apl.Value]apl.Value)}
+	for i, k := range keys {
+		d.K[i] = apl.String(k)
+		d.M[d.K[i]] = values[i]
+	}
+	return &d
+}
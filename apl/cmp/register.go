@@ -0,0 +1,19 @@
+// Package cmp compares values for debugging.
+//
+//	L cmp→diff R    positions and values where L and R do not match
+package cmp
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the cmp package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "cmp"
+	}
+	pkg := map[string]apl.Value{
+		"diff": apl.ToFunction(diff),
+	}
+	a.RegisterPackage(name, pkg)
+}
@@ -0,0 +1,145 @@
+package comb
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// perm returns all permutations of R.
+// The empty vector has a single empty permutation.
+func perm(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("perm: cannot be called dyadically")
+	}
+	v, err := vector(a, R)
+	if err != nil {
+		return nil, fmt.Errorf("perm: %s", err)
+	}
+	n := v.Size()
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	var res apl.List
+	for {
+		res = append(res, pick(a, v, idx))
+		if nextPerm(idx) == false {
+			return res, nil
+		}
+	}
+}
+
+// choose returns all L-combinations of R.
+// If L is 0, there is a single empty combination, if L exceeds the length of R there is none.
+func choose(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("choose: cannot be called monadically")
+	}
+	num, ok := L.(apl.Number)
+	if ok == false {
+		return nil, fmt.Errorf("choose: L must be a number: %T", L)
+	}
+	k, ok := num.ToIndex()
+	if ok == false || k < 0 {
+		return nil, fmt.Errorf("choose: L must be a non-negative integer: %s", L.String(a.Format))
+	}
+	v, err := vector(a, R)
+	if err != nil {
+		return nil, fmt.Errorf("choose: %s", err)
+	}
+	n := v.Size()
+	res := apl.List{}
+	if k > n {
+		return res, nil
+	}
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	for {
+		res = append(res, pick(a, v, idx))
+		if nextComb(idx, n) == false {
+			return res, nil
+		}
+	}
+}
+
+// nextPerm advances idx to the next permutation in lexicographic order.
+// It returns false, if idx is the last permutation.
+func nextPerm(idx []int) bool {
+	i := len(idx) - 2
+	for i >= 0 && idx[i] >= idx[i+1] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	k := len(idx) - 1
+	for idx[k] <= idx[i] {
+		k--
+	}
+	idx[i], idx[k] = idx[k], idx[i]
+	for l, r := i+1, len(idx)-1; l < r; l, r = l+1, r-1 {
+		idx[l], idx[r] = idx[r], idx[l]
+	}
+	return true
+}
+
+// nextComb advances the ascending indexes idx to the next combination out of n.
+// It returns false, if idx is the last combination.
+func nextComb(idx []int, n int) bool {
+	k := len(idx)
+	i := k - 1
+	for i >= 0 && idx[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	idx[i]++
+	for j := i + 1; j < k; j++ {
+		idx[j] = idx[j-1] + 1
+	}
+	return true
+}
+
+// pick returns the elements of v at the indexes idx as a vector.
+func pick(a *apl.Apl, v apl.Array, idx []int) apl.Value {
+	if len(idx) == 0 {
+		return apl.EmptyArray{}
+	}
+	values := make([]apl.Value, len(idx))
+	for i, k := range idx {
+		values[i] = v.At(k).Copy()
+	}
+	for i := range values {
+		if _, ok := values[i].(apl.Array); ok {
+			return apl.List(values)
+		}
+	}
+	return a.UnifyArray(apl.MixedArray{Dims: []int{len(values)}, Values: values})
+}
+
+// vector returns R as a vector.
+// A scalar integer n is converted to ⍳n.
+func vector(a *apl.Apl, R apl.Value) (apl.Array, error) {
+	if num, ok := R.(apl.Number); ok {
+		if n, ok := num.ToIndex(); ok == false || n < 0 {
+			return nil, fmt.Errorf("scalar argument must be a non-negative integer: %s", R.String(a.Format))
+		}
+		i, err := apl.Primitive("⍳").Call(a, nil, R)
+		if err != nil {
+			return nil, err
+		}
+		R = i
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return apl.List{R}, nil
+	}
+	if n := len(ar.Shape()); n > 1 {
+		return nil, fmt.Errorf("argument must be a vector: rank %d", n)
+	}
+	return ar, nil
+}
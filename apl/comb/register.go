@@ -0,0 +1,24 @@
+// Package comb provides combinatorial functions.
+//
+// The results are lists of vectors, in lexicographic order of the indexes.
+// A scalar integer argument n stands for ⍳n and depends on ⎕IO.
+//
+//	comb→perm R        all permutations of the vector R
+//	L comb→choose R    all L-combinations of the vector R
+package comb
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the comb package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "comb"
+	}
+	pkg := map[string]apl.Value{
+		"perm":   apl.ToFunction(perm),
+		"choose": apl.ToFunction(choose),
+	}
+	a.RegisterPackage(name, pkg)
+}
@@ -0,0 +1,135 @@
+package apl
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInterrupted is returned by an evaluation that is stopped by Interrupt.
+var ErrInterrupted = errors.New("interrupted")
+
+// ErrTimeout is returned by EvalTimeout, if the evaluation exceeds the duration.
+var ErrTimeout = errors.New("timeout")
+
+// SetContext sets the context of the interpreter.
+// It is passed to go functions which accept a context.Context as their first argument,
+// see package xgo.
+// A long running go function can return early, if the context is canceled.
+func (a *Apl) SetContext(ctx context.Context) {
+	a.ctx = ctx
+	a.intr.reset()
+}
+
+// Context returns the context of the running evaluation.
+// It is derived from the context set by SetContext or from context.Background
+// and is canceled by Interrupt.
+func (a *Apl) Context() context.Context {
+	return a.intr.context(a.ctx)
+}
+
+// Interrupt stops a running evaluation at the next safe point.
+// The evaluation returns ErrInterrupted.
+// Interrupt may be called from another goroutine, e.g. on a signal in a REPL.
+// It also cancels the context passed to go functions.
+//
+// The interrupt is pending until the top-level evaluation returns.
+// If no evaluation is running, the next one is stopped.
+func (a *Apl) Interrupt() {
+	a.intr.stop(ErrInterrupted)
+}
+
+// EvalTimeout evaluates the program like Eval.
+// If it takes longer than d, it is stopped like with Interrupt and returns ErrTimeout.
+func (a *Apl) EvalTimeout(p Program, d time.Duration) error {
+	defer a.intr.enter()()
+	t := time.AfterFunc(d, func() { a.intr.stop(ErrTimeout) })
+	defer t.Stop()
+	return a.eval(p)
+}
+
+// Interrupted returns ErrInterrupted or ErrTimeout, if the evaluation has been stopped.
+// If the context set by SetContext is done, it returns it's error instead.
+// It is called at safe points, such as in loops of operators and in lambda calls.
+func (a *Apl) Interrupted() error {
+	if a.ctx != nil && a.ctx.Err() != nil {
+		return a.ctx.Err()
+	}
+	a.intr.Lock()
+	defer a.intr.Unlock()
+	return a.intr.err
+}
+
+// interrupt holds the cancelable context of an evaluation.
+// It is shared with forks of the interpreter.
+type interrupt struct {
+	sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	err    error // reason for the cancelation
+	depth  int   // number of running evaluations, including nested ones and forks
+}
+
+// context returns the current context or derives a new one from parent.
+// It is canceled already, if an interrupt is pending.
+func (i *interrupt) context(parent context.Context) context.Context {
+	i.Lock()
+	defer i.Unlock()
+	if i.ctx == nil {
+		if parent == nil {
+			parent = context.Background()
+		}
+		i.ctx, i.cancel = context.WithCancel(parent)
+		if i.err != nil {
+			i.cancel()
+		}
+	}
+	return i.ctx
+}
+
+// stop sets the reason of the interrupt and cancels the context.
+// The first reason is kept, if it is stopped multiple times.
+func (i *interrupt) stop(err error) {
+	i.Lock()
+	defer i.Unlock()
+	if i.err == nil {
+		i.err = err
+	}
+	if i.cancel != nil {
+		i.cancel()
+	}
+}
+
+// enter is called at the start of an evaluation.
+// It returns the function to be called at the end.
+// When the top-level evaluation ends, a pending interrupt is consumed.
+func (i *interrupt) enter() func() {
+	i.Lock()
+	i.depth++
+	i.Unlock()
+	return func() {
+		i.Lock()
+		defer i.Unlock()
+		i.depth--
+		if i.depth == 0 && i.err != nil {
+			i.err = nil
+			i.drop()
+		}
+	}
+}
+
+// reset drops the current context, such that it is derived again from a new parent.
+// A pending interrupt is kept.
+func (i *interrupt) reset() {
+	i.Lock()
+	defer i.Unlock()
+	i.drop()
+}
+
+func (i *interrupt) drop() {
+	if i.ctx != nil {
+		i.cancel()
+		i.ctx, i.cancel = nil, nil
+	}
+}
@@ -0,0 +1,62 @@
+package date
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ktye/iv/apl"
+)
+
+// addMonths adds L calendar months to the times R.
+// If the day does not exist in the target month, it is clamped to the last day:
+//
+//	1 date→addmonths 2019.01.31    2019.02.28T00.00.00.000
+//
+// The time of the day is kept.
+func addMonths(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	n, err := count("addmonths", L)
+	if err != nil {
+		return nil, err
+	}
+	return eachTime("addmonths", R, func(t time.Time) (time.Time, error) {
+		return addDate(t, 0, n), nil
+	})
+}
+
+// addYears adds L calendar years to the times R.
+// February 29 becomes February 28 in a year that is not a leap year.
+func addYears(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	n, err := count("addyears", L)
+	if err != nil {
+		return nil, err
+	}
+	return eachTime("addyears", R, func(t time.Time) (time.Time, error) {
+		return addDate(t, n, 0), nil
+	})
+}
+
+// addDate adds years and months with time.AddDate.
+// AddDate normalizes overflowing days into the next month, e.g. Jan 31 + 1 month is Mar 3.
+// Instead, the day is clamped to the end of the target month.
+func addDate(t time.Time, years, months int) time.Time {
+	Y, M, D := t.Date()
+	first := time.Date(Y, M, 1, 0, 0, 0, 0, t.Location()).AddDate(years, months, 0)
+	if last := first.AddDate(0, 1, -1).Day(); D > last {
+		D = last
+	}
+	h, m, s := t.Clock()
+	return time.Date(first.Year(), first.Month(), D, h, m, s, t.Nanosecond(), t.Location())
+}
+
+// count returns L as an integer.
+func count(name string, L apl.Value) (int, error) {
+	if L == nil {
+		return 0, fmt.Errorf("%s: cannot be called monadically", name)
+	}
+	if n, ok := L.(apl.Number); ok {
+		if i, ok := n.ToIndex(); ok {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s: L must be an integer: %T", name, L)
+}
@@ -0,0 +1,55 @@
+package date
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// duration parses a string or an array of strings as durations:
+//
+//	date→duration "1h30m"       1h30m0s
+//	date→duration "¯1h 30m"     ¯1h30m0s
+//
+// A duration value is returned unchanged.
+func duration(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("duration: cannot be called dyadically")
+	}
+	parse := func(v apl.Value) (time.Duration, error) {
+		if t, ok := v.(numbers.Time); ok {
+			if d, ok := t.Duration(); ok {
+				return d, nil
+			}
+			return 0, fmt.Errorf("duration: argument is a time")
+		}
+		s, ok := v.(apl.String)
+		if ok == false {
+			return 0, fmt.Errorf("duration: argument must be a string: %T", v)
+		}
+		d, ok := numbers.ParseDuration(string(s))
+		if ok == false {
+			return 0, fmt.Errorf("duration: cannot parse %q", string(s))
+		}
+		return d, nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		d, err := parse(R)
+		if err != nil {
+			return nil, err
+		}
+		return numbers.NewDuration(d), nil
+	}
+	res := numbers.TimeArray{Dims: apl.CopyShape(ar), Times: make([]time.Time, ar.Size())}
+	for i := range res.Times {
+		d, err := parse(ar.At(i))
+		if err != nil {
+			return nil, err
+		}
+		res.Times[i] = time.Time(numbers.NewDuration(d))
+	}
+	return res, nil
+}
@@ -0,0 +1,45 @@
+// Package date provides calendar functions for time values.
+//
+// Time zones are names of the IANA time zone database, such as "Europe/Berlin", "UTC" or "Local".
+// Parsed times are in UTC.
+//
+//	L date→convert R        the same instant as R in the time zone L
+//	L date→attach R         the wall clock time of R in the time zone L
+//	date→zone R             name of the time zone of R
+//	date→duration R         parse a duration string such as "1h30m"
+//	L date→addmonths R      add L calendar months to R
+//	L date→addyears R       add L calendar years to R
+//	L date→weekend R        test if R is on a non-working weekday (ISO 1-7, L defaults to 6 7)
+//	L date→addworkdays R    add L working days to R
+//	L date→tounix R         seconds (L "ms": milliseconds) since 1970.01.01 UTC
+//	L date→fromunix R       UTC time from seconds (L "ms": milliseconds) since the epoch
+//
+// Examples:
+//
+//	"Europe/Berlin" date→convert 2019.07.01T12.00    2019.07.01T14.00.00.000 CEST
+//	"Europe/Berlin" date→attach 2019.07.01T12.00     2019.07.01T12.00.00.000 CEST
+package date
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the date package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "date"
+	}
+	pkg := map[string]apl.Value{
+		"convert":     apl.ToFunction(convert),
+		"attach":      apl.ToFunction(attach),
+		"zone":        apl.ToFunction(zone),
+		"duration":    apl.ToFunction(duration),
+		"addmonths":   apl.ToFunction(addMonths),
+		"addyears":    apl.ToFunction(addYears),
+		"weekend":     apl.ToFunction(weekend),
+		"addworkdays": apl.ToFunction(addWorkdays),
+		"tounix":      apl.ToFunction(toUnix),
+		"fromunix":    apl.ToFunction(fromUnix),
+	}
+	a.RegisterPackage(name, pkg)
+}
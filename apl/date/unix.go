@@ -0,0 +1,118 @@
+package date
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// toUnix returns the seconds since 1970.01.01 UTC.
+// Fractions of a second are returned as a float.
+// With L "ms" it returns an integer number of milliseconds:
+//
+//	date→tounix 2019.01.01T00.00.01.5       1546300801.5
+//	"ms" date→tounix 2019.01.01T00.00.01.5  1546300801500
+func toUnix(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	ms, err := unixUnit(a, "tounix", L)
+	if err != nil {
+		return nil, err
+	}
+	conv := func(v apl.Value) (apl.Value, error) {
+		t, ok := v.(numbers.Time)
+		if ok == false {
+			return nil, fmt.Errorf("tounix: argument must be a time: %T", v)
+		} else if _, ok := t.Duration(); ok {
+			return nil, fmt.Errorf("tounix: argument is a duration")
+		}
+		ns := time.Time(t).UnixNano()
+		if ms {
+			return apl.Int(ns / 1e6), nil
+		} else if ns%1e9 == 0 {
+			return apl.Int(ns / 1e9), nil
+		}
+		return numbers.Float(float64(ns) / 1e9), nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return conv(R)
+	}
+	res := apl.MixedArray{Dims: apl.CopyShape(ar), Values: make([]apl.Value, ar.Size())}
+	for i := range res.Values {
+		if res.Values[i], err = conv(ar.At(i)); err != nil {
+			return nil, err
+		}
+	}
+	return a.UnifyArray(res), nil
+}
+
+// fromUnix returns the UTC time for the seconds or milliseconds (L "ms") since 1970.01.01 UTC.
+// The time must be representable in nanoseconds as an int64, which are the years 1678 to 2262.
+func fromUnix(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	ms, err := unixUnit(a, "fromunix", L)
+	if err != nil {
+		return nil, err
+	}
+	unit := 1e9
+	if ms {
+		unit = 1e6
+	}
+	conv := func(v apl.Value) (time.Time, error) {
+		var f float64
+		switch n := v.(type) {
+		case apl.Bool:
+			if n {
+				f = 1
+			}
+		case apl.Int:
+			if max := math.MaxInt64 / int64(unit); int64(n) > max || int64(n) < -max {
+				return time.Time{}, fmt.Errorf("fromunix: argument is out of range: %s", v.String(a.Format))
+			}
+			return time.Unix(0, int64(n)*int64(unit)).UTC(), nil
+		case numbers.Float:
+			f = float64(n)
+		default:
+			return time.Time{}, fmt.Errorf("fromunix: argument must be a real number: %T", v)
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return time.Time{}, fmt.Errorf("fromunix: argument must be finite")
+		} else if math.Abs(f*unit) >= math.MaxInt64 {
+			return time.Time{}, fmt.Errorf("fromunix: argument is out of range: %s", v.String(a.Format))
+		}
+		sec, frac := math.Modf(f * unit / 1e9)
+		return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC(), nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		t, err := conv(R)
+		if err != nil {
+			return nil, err
+		}
+		return numbers.Time(t), nil
+	}
+	res := numbers.TimeArray{Dims: apl.CopyShape(ar), Times: make([]time.Time, ar.Size())}
+	for i := range res.Times {
+		if res.Times[i], err = conv(ar.At(i)); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// unixUnit returns true, if L is "ms". Without L, the unit is seconds.
+func unixUnit(a *apl.Apl, name string, L apl.Value) (bool, error) {
+	if L == nil {
+		return false, nil
+	}
+	if s, ok := L.(apl.String); ok {
+		switch s {
+		case "s":
+			return false, nil
+		case "ms":
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%s: L must be \"s\" or \"ms\": %s", name, L.String(a.Format))
+}
@@ -0,0 +1,158 @@
+package date
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// Weekdays are numbered as in ISO 8601 from 1 (Monday) to 7 (Sunday).
+// The default non-working days are Saturday and Sunday.
+var defaultWeekend = []int{6, 7}
+
+// weekend returns 1 for the times R that fall on a non-working day.
+// The non-working weekdays may be given as L:
+//
+//	date→weekend 2019.01.05         1
+//	5 6 date→weekend 2019.01.05     1
+//	5 6 date→weekend 2019.01.06     0
+func weekend(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	off := defaultWeekend
+	if L != nil {
+		var err error
+		if off, err = weekdays("weekend", L); err != nil {
+			return nil, err
+		}
+	}
+	is := func(v apl.Value) (apl.Bool, error) {
+		t, ok := v.(numbers.Time)
+		if ok == false {
+			return false, fmt.Errorf("weekend: argument must be a time: %T", v)
+		} else if _, ok := t.Duration(); ok {
+			return false, fmt.Errorf("weekend: argument is a duration")
+		}
+		return apl.Bool(isOff(time.Time(t), off)), nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return is(R)
+	}
+	res := apl.BoolArray{Dims: apl.CopyShape(ar), Bools: make([]bool, ar.Size())}
+	for i := range res.Bools {
+		b, err := is(ar.At(i))
+		if err != nil {
+			return nil, err
+		}
+		res.Bools[i] = bool(b)
+	}
+	return res, nil
+}
+
+// addWorkdays adds L working days to the times R.
+// L is the number of days or a list of the number and the non-working weekdays:
+//
+//	2 date→addworkdays 2019.01.04           2019.01.08T00.00.00.000
+//	(2;5 6;) date→addworkdays 2019.01.03    2019.01.07T00.00.00.000
+//
+// Negative numbers go back in time.
+// Zero days return R unchanged, even if it is not a working day.
+func addWorkdays(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("addworkdays: cannot be called monadically")
+	}
+	off := defaultWeekend
+	if l, ok := L.(apl.List); ok {
+		if len(l) != 2 {
+			return nil, fmt.Errorf("addworkdays: L must be a number or a list of the number and the weekend days")
+		}
+		var err error
+		if off, err = weekdays("addworkdays", l[1]); err != nil {
+			return nil, err
+		}
+		L = l[0]
+	}
+	n, err := count("addworkdays", L)
+	if err != nil {
+		return nil, err
+	}
+	step := 1
+	if n < 0 {
+		n, step = -n, -1
+	}
+
+	// Each week has the same number of working days.
+	// Whole weeks are added at once, the remaining days are counted.
+	// The remainder is at least 1, to end on a working day.
+	weeks := 0
+	if n > 0 {
+		weeks = (n - 1) / workdays(off)
+		n -= weeks * workdays(off)
+	}
+	return eachTime("addworkdays", R, func(t time.Time) (time.Time, error) {
+		if err := a.Interrupted(); err != nil {
+			return t, err
+		}
+		t = t.AddDate(0, 0, 7*weeks*step)
+		for i := 0; i < n; {
+			t = t.AddDate(0, 0, step)
+			if isOff(t, off) == false {
+				i++
+			}
+		}
+		return t, nil
+	})
+}
+
+// workdays returns the number of working days per week.
+func workdays(off []int) int {
+	m := make(map[int]bool)
+	for _, d := range off {
+		m[d] = true
+	}
+	return 7 - len(m)
+}
+
+// weekdays returns the ISO weekday numbers of the scalar or vector v.
+func weekdays(name string, v apl.Value) ([]int, error) {
+	ar, ok := v.(apl.Array)
+	if ok == false {
+		ar = apl.MixedArray{Dims: []int{1}, Values: []apl.Value{v}}
+	}
+	d := make([]int, ar.Size())
+	for i := range d {
+		var day int
+		if n, ok := ar.At(i).(apl.Number); ok {
+			day, ok = n.ToIndex()
+		}
+		if day < 1 || day > 7 {
+			return nil, fmt.Errorf("%s: weekdays must be numbers from 1 (Monday) to 7 (Sunday)", name)
+		}
+		d[i] = day
+	}
+	if len(d) > 0 {
+		all := make(map[int]bool)
+		for _, day := range d {
+			all[day] = true
+		}
+		if len(all) == 7 {
+			return nil, fmt.Errorf("%s: every day is a weekend day", name)
+		}
+	}
+	return d, nil
+}
+
+// isOff returns if t is on one of the non-working ISO weekdays.
+func isOff(t time.Time, off []int) bool {
+	wd := int(t.Weekday())
+	if wd == 0 {
+		wd = 7
+	}
+	for _, d := range off {
+		if d == wd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package date
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// convert returns the times R in the location L.
+// The instants do not change, R and the result compare equal.
+func convert(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	loc, err := location("convert", L)
+	if err != nil {
+		return nil, err
+	}
+	return eachTime("convert", R, func(t time.Time) (time.Time, error) {
+		return t.In(loc), nil
+	})
+}
+
+// attach interprets the wall clock of the times R in the location L.
+// A wall clock time that does not exist, because it falls into a daylight saving gap,
+// is normalized by go's time.Date, e.g. 02:30 becomes 03:30 on the day clocks move forward.
+// A wall clock time that occurs twice when clocks move back, is the first of them.
+func attach(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	loc, err := location("attach", L)
+	if err != nil {
+		return nil, err
+	}
+	return eachTime("attach", R, func(t time.Time) (time.Time, error) {
+		return wallclock(t, loc), nil
+	})
+}
+
+// wallclock returns the earliest instant in loc, that shows the wall clock of t.
+// Time.Date does not guarantee which one it returns for an ambiguous wall clock.
+// The candidates use the offsets of loc a day before and after.
+func wallclock(t time.Time, loc *time.Location) time.Time {
+	Y, M, D := t.Date()
+	h, m, s := t.Clock()
+	u := time.Date(Y, M, D, h, m, s, t.Nanosecond(), loc)
+	w := time.Date(Y, M, D, h, m, s, t.Nanosecond(), time.UTC)
+	first := u
+	for _, c := range []time.Time{u.Add(-24 * time.Hour), u, u.Add(24 * time.Hour)} {
+		_, off := c.Zone()
+		x := w.Add(-time.Duration(off) * time.Second).In(loc)
+		if x.Before(first) && sameClock(x, w) {
+			first = x
+		}
+	}
+	return first
+}
+
+// sameClock returns if the wall clocks of x and y are equal, ignoring their locations.
+func sameClock(x, y time.Time) bool {
+	Y, M, D := x.Date()
+	h, m, s := x.Clock()
+	return time.Date(Y, M, D, h, m, s, x.Nanosecond(), time.UTC).Equal(y)
+}
+
+// zone returns the name of the location of R.
+func zone(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("zone: cannot be called dyadically")
+	}
+	t, ok := R.(numbers.Time)
+	if ok == false {
+		return nil, fmt.Errorf("zone: argument must be a time: %T", R)
+	}
+	return apl.String(time.Time(t).Location().String()), nil
+}
+
+// location loads the time zone with the name L.
+func location(name string, L apl.Value) (*time.Location, error) {
+	if L == nil {
+		return nil, fmt.Errorf("%s: cannot be called monadically", name)
+	}
+	s, ok := L.(apl.String)
+	if ok == false {
+		return nil, fmt.Errorf("%s: L must be a time zone name: %T", name, L)
+	}
+	loc, err := time.LoadLocation(string(s))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	return loc, nil
+}
+
+// eachTime applies f to a time or to each element of an array of times.
+// Durations are not accepted.
+func eachTime(name string, R apl.Value, f func(time.Time) (time.Time, error)) (apl.Value, error) {
+	do := func(v apl.Value) (time.Time, error) {
+		t, ok := v.(numbers.Time)
+		if ok == false {
+			return time.Time{}, fmt.Errorf("%s: argument must be a time: %T", name, v)
+		} else if _, ok := t.Duration(); ok {
+			return time.Time{}, fmt.Errorf("%s: argument is a duration", name)
+		}
+		return f(time.Time(t))
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		t, err := do(R)
+		if err != nil {
+			return nil, err
+		}
+		return numbers.Time(t), nil
+	}
+	res := numbers.TimeArray{Dims: apl.CopyShape(ar), Times: make([]time.Time, ar.Size())}
+	for i := range res.Times {
+		t, err := do(ar.At(i))
+		if err != nil {
+			return nil, err
+		}
+		res.Times[i] = t
+	}
+	return res, nil
+}
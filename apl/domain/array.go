@@ -136,6 +136,9 @@ type boolarray struct {
 }
 
 func (ba boolarray) To(a *apl.Apl, V apl.Value) (apl.Value, bool) {
+	if b, ok := V.(apl.BitArray); ok {
+		V = b.Bools()
+	}
 	_, ok := V.(apl.BoolArray)
 	if ba.conv == false && ok == false {
 		return V, false
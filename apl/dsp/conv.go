@@ -0,0 +1,155 @@
+package dsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ktye/iv/apl"
+)
+
+// conv returns the discrete convolution of two vectors.
+//
+// The mode is given by a list as the left argument: (L;'same';).
+// Modes are:
+//
+//	full   all overlapping positions (default), length M+N-1
+//	same   the central part with the length of the longer vector
+//	valid  only complete overlaps, length |M-N|+1
+//
+// The computation is done directly with the primitive functions
+// + and × of the current numeric tower.
+func conv(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	x, mode, err := modeArg(L)
+	if err != nil {
+		return nil, fmt.Errorf("conv: %s", err)
+	}
+	y, err := vector(R)
+	if err != nil {
+		return nil, fmt.Errorf("conv: %s", err)
+	}
+	v, err := convolve(a, x, y, mode)
+	if err != nil {
+		return nil, fmt.Errorf("conv: %s", err)
+	}
+	return v, nil
+}
+
+// corr returns the cross-correlation of two vectors.
+// It is the convolution of L with the reversed complex conjugate of R.
+// The mode is selected as for conv.
+func corr(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	x, mode, err := modeArg(L)
+	if err != nil {
+		return nil, fmt.Errorf("corr: %s", err)
+	}
+	y, err := vector(R)
+	if err != nil {
+		return nil, fmt.Errorf("corr: %s", err)
+	}
+	r := make([]apl.Value, len(y))
+	for i := range y {
+		if r[len(y)-1-i], err = apl.Primitive("+").Call(a, nil, y[i]); err != nil {
+			return nil, fmt.Errorf("corr: %s", err)
+		}
+	}
+	v, err := convolve(a, x, r, mode)
+	if err != nil {
+		return nil, fmt.Errorf("corr: %s", err)
+	}
+	return v, nil
+}
+
+func convolve(a *apl.Apl, x, y []apl.Value, mode string) (apl.Value, error) {
+	m, n := len(x), len(y)
+	if m == 0 || n == 0 {
+		return apl.EmptyArray{}, nil
+	}
+	full := make([]apl.Value, m+n-1)
+	for i := range full {
+		full[i] = apl.Int(0)
+	}
+	for i := range x {
+		for k := range y {
+			p, err := apl.Primitive("×").Call(a, x[i], y[k])
+			if err != nil {
+				return nil, err
+			}
+			if full[i+k], err = apl.Primitive("+").Call(a, full[i+k], p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	min, max := m, n
+	if min > max {
+		min, max = max, min
+	}
+	var r []apl.Value
+	switch mode {
+	case "full":
+		r = full
+	case "same":
+		off := (min - 1) / 2
+		r = full[off : off+max]
+	case "valid":
+		r = full[min-1 : max]
+	default:
+		return nil, fmt.Errorf("unknown mode: %s", mode)
+	}
+	u, _ := a.Unify(apl.MixedArray{Dims: []int{len(r)}, Values: r}, true)
+	return u, nil
+}
+
+// modeArg splits the left argument into the vector and the mode.
+// L is either a vector or a list (vector;mode;).
+func modeArg(L apl.Value) ([]apl.Value, string, error) {
+	if L == nil {
+		return nil, "", fmt.Errorf("left argument missing")
+	}
+	l, ok := L.(apl.List)
+	if ok == false {
+		x, err := vector(L)
+		return x, "full", err
+	} else if len(l) != 2 {
+		return nil, "", fmt.Errorf("left argument must be a list (vector;mode;)")
+	}
+	x, err := vector(l[0])
+	if err != nil {
+		return nil, "", err
+	}
+	switch m := l[1].(type) {
+	case apl.String:
+		return x, string(m), nil
+	case apl.Array:
+		var b strings.Builder
+		for i := 0; i < m.Size(); i++ {
+			c, ok := m.At(i).(apl.String)
+			if ok == false {
+				return nil, "", fmt.Errorf("mode must be a string")
+			}
+			b.WriteString(string(c))
+		}
+		return x, b.String(), nil
+	default:
+		return nil, "", fmt.Errorf("mode must be a string")
+	}
+}
+
+// vector returns the elements of a vector.
+// A scalar is a vector of length 1.
+func vector(R apl.Value) ([]apl.Value, error) {
+	if _, ok := R.(apl.EmptyArray); ok {
+		return nil, nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return []apl.Value{R}, nil
+	} else if len(ar.Shape()) != 1 {
+		return nil, fmt.Errorf("argument must be a vector: rank %d", len(ar.Shape()))
+	}
+	v := make([]apl.Value, ar.Size())
+	for i := range v {
+		v[i] = ar.At(i)
+	}
+	return v, nil
+}
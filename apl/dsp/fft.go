@@ -0,0 +1,127 @@
+package dsp
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// fft returns the discrete fourier transform of a vector.
+//
+// The transform is a radix-2 FFT.
+// The length of the vector must be a power of 2.
+// It is not padded with zeros, as that would change the frequency resolution
+// silently. Padding can be done explicitly with take, e.g. 8↑R.
+func fft(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("fft: cannot be called dyadically")
+	}
+	x, err := complexVector(R)
+	if err != nil {
+		return nil, fmt.Errorf("fft: %s", err)
+	}
+	if err := transform(x, false); err != nil {
+		return nil, fmt.Errorf("fft: %s", err)
+	}
+	return numbers.ComplexArray{Dims: []int{len(x)}, Cmplx: x}, nil
+}
+
+// ifft returns the inverse discrete fourier transform of a vector.
+// It is scaled by 1÷N, such that ifft fft R is R.
+func ifft(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("ifft: cannot be called dyadically")
+	}
+	x, err := complexVector(R)
+	if err != nil {
+		return nil, fmt.Errorf("ifft: %s", err)
+	}
+	if err := transform(x, true); err != nil {
+		return nil, fmt.Errorf("ifft: %s", err)
+	}
+	return numbers.ComplexArray{Dims: []int{len(x)}, Cmplx: x}, nil
+}
+
+// transform computes the fft of x in place.
+// The inverse transform uses the conjugate twiddle factors and is scaled by 1/N.
+func transform(x []complex128, inverse bool) error {
+	n := len(x)
+	if n == 0 {
+		return nil
+	} else if n&(n-1) != 0 {
+		return fmt.Errorf("length is not a power of 2: %d", n)
+	}
+
+	// Bit reversal permutation.
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	sign := -1.0
+	if inverse {
+		sign = 1.0
+	}
+	for size := 2; size <= n; size <<= 1 {
+		w := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			wk := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				u := x[start+k]
+				v := wk * x[start+k+size/2]
+				x[start+k] = u + v
+				x[start+k+size/2] = u - v
+				wk *= w
+			}
+		}
+	}
+
+	if inverse {
+		s := complex(1/float64(n), 0)
+		for i := range x {
+			x[i] *= s
+		}
+	}
+	return nil
+}
+
+// complexVector converts a numeric vector to complex128.
+// A scalar is a vector of length 1.
+func complexVector(R apl.Value) ([]complex128, error) {
+	if _, ok := R.(apl.EmptyArray); ok {
+		return []complex128{}, nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		ar = apl.MixedArray{Dims: []int{1}, Values: []apl.Value{R}}
+	} else if len(ar.Shape()) != 1 {
+		return nil, fmt.Errorf("argument must be a vector: rank %d", len(ar.Shape()))
+	}
+	x := make([]complex128, ar.Size())
+	for i := range x {
+		switch v := ar.At(i).(type) {
+		case apl.Bool:
+			if v {
+				x[i] = 1
+			}
+		case apl.Int:
+			x[i] = complex(float64(v), 0)
+		case numbers.Float:
+			x[i] = complex(float64(v), 0)
+		case numbers.Complex:
+			x[i] = complex128(v)
+		default:
+			return nil, fmt.Errorf("argument must be numeric: %T", v)
+		}
+	}
+	return x, nil
+}
@@ -0,0 +1,38 @@
+// Package dsp provides signal processing functions.
+//
+// Signals are numeric vectors.
+// Results are computed in float64 or complex128 and returned as
+// uniform arrays of the numbers package.
+//
+//	dsp→fft R         discrete fourier transform
+//	dsp→ifft R        inverse discrete fourier transform
+//	L dsp→conv R      convolution
+//	L dsp→corr R      cross-correlation
+//	dsp→hann R        multiply with a Hann window
+//	dsp→hamming R     multiply with a Hamming window
+//	L dsp→decimate R  keep every L'th sample
+//	L dsp→resample R  linear interpolation to length L
+package dsp
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the dsp package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "dsp"
+	}
+	pkg := map[string]apl.Value{
+		"fft":  apl.ToFunction(fft),
+		"ifft": apl.ToFunction(ifft),
+		"conv": apl.ToFunction(conv),
+		"corr": apl.ToFunction(corr),
+
+		"hann":     apl.ToFunction(hann),
+		"hamming":  apl.ToFunction(hamming),
+		"decimate": apl.ToFunction(decimate),
+		"resample": apl.ToFunction(resample),
+	}
+	a.RegisterPackage(name, pkg)
+}
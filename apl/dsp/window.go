@@ -0,0 +1,135 @@
+package dsp
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// hann multiplies a real vector with the symmetric Hann window.
+// The window coefficients are returned for a vector of ones, e.g. hann 8⍴1.
+func hann(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return window(a, L, R, "hann", func(n, N int) float64 {
+		return 0.5 - 0.5*math.Cos(2*math.Pi*float64(n)/float64(N-1))
+	})
+}
+
+// hamming multiplies a real vector with the symmetric Hamming window.
+func hamming(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return window(a, L, R, "hamming", func(n, N int) float64 {
+		return 0.54 - 0.46*math.Cos(2*math.Pi*float64(n)/float64(N-1))
+	})
+}
+
+func window(a *apl.Apl, L, R apl.Value, name string, w func(n, N int) float64) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("%s: cannot be called dyadically", name)
+	}
+	x, err := floatVector(R)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	if N := len(x); N > 1 {
+		for n := range x {
+			x[n] *= w(n, N)
+		}
+	}
+	return numbers.FloatArray{Dims: []int{len(x)}, Floats: x}, nil
+}
+
+// decimate keeps every L'th sample of R, starting with the first.
+// It does not apply an anti-aliasing filter.
+// If needed, R should be low-pass filtered before, e.g. with conv.
+func decimate(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	k, err := positive(L)
+	if err != nil {
+		return nil, fmt.Errorf("decimate: %s", err)
+	}
+	x, err := vector(R)
+	if err != nil {
+		return nil, fmt.Errorf("decimate: %s", err)
+	}
+	var r []apl.Value
+	for i := 0; i < len(x); i += k {
+		r = append(r, x[i])
+	}
+	if len(r) == 0 {
+		return apl.EmptyArray{}, nil
+	}
+	return a.UnifyArray(apl.MixedArray{Dims: []int{len(r)}, Values: r}), nil
+}
+
+// resample interpolates R linearly to a vector of length L.
+// The first and last samples are kept.
+func resample(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	m, err := positive(L)
+	if err != nil {
+		return nil, fmt.Errorf("resample: %s", err)
+	}
+	x, err := floatVector(R)
+	if err != nil {
+		return nil, fmt.Errorf("resample: %s", err)
+	}
+	n := len(x)
+	if n == 0 {
+		return nil, fmt.Errorf("resample: empty argument")
+	}
+	r := make([]float64, m)
+	for i := range r {
+		if m == 1 || n == 1 {
+			r[i] = x[0]
+			continue
+		}
+		t := float64(i) * float64(n-1) / float64(m-1)
+		k := int(t)
+		if k >= n-1 {
+			r[i] = x[n-1]
+			continue
+		}
+		f := t - float64(k)
+		r[i] = (1-f)*x[k] + f*x[k+1]
+	}
+	return numbers.FloatArray{Dims: []int{m}, Floats: r}, nil
+}
+
+// positive converts L to a positive integer.
+func positive(L apl.Value) (int, error) {
+	if L == nil {
+		return 0, fmt.Errorf("left argument missing")
+	}
+	n, ok := L.(apl.Number)
+	if ok == false {
+		return 0, fmt.Errorf("left argument must be a positive integer")
+	}
+	k, ok := n.ToIndex()
+	if ok == false || k < 1 {
+		return 0, fmt.Errorf("left argument must be a positive integer")
+	}
+	return k, nil
+}
+
+// floatVector converts a real vector to float64.
+func floatVector(R apl.Value) ([]float64, error) {
+	v, err := vector(R)
+	if err != nil {
+		return nil, err
+	}
+	x := make([]float64, len(v))
+	for i := range v {
+		switch n := v[i].(type) {
+		case apl.Bool:
+			if n {
+				x[i] = 1
+			}
+		case apl.Int:
+			x[i] = float64(n)
+		case numbers.Float:
+			x[i] = float64(n)
+		default:
+			return nil, fmt.Errorf("argument must be real: %T", v[i])
+		}
+	}
+	return x, nil
+}
@@ -1,10 +1,19 @@
 package apl
 
+import "fmt"
+
 // Error carries an error value.
 // It is used by go routines to signal errors.
-// To send err over Channel c, use: c[0]<-Error{e}
+// To send err over Channel c, use: c[0]<-Error{E: e}
+//
+// Error is also a first class value, that is passed to an error handler
+// by the catch operator (f⎊g). It is an object with the keys message and code:
+//	E[`message]   ⍝ error message
+//	E[`code]      ⍝ error code, 0 if not set
+// It can be signaled again with ↯E.
 type Error struct {
-	E error
+	E    error
+	Code int
 }
 
 func (e Error) String(f Format) string {
@@ -14,3 +23,29 @@ func (e Error) String(f Format) string {
 	return e.E.Error()
 }
 func (e Error) Copy() Value { return e }
+
+// Error implements the go error interface.
+func (e Error) Error() string {
+	if e.E == nil {
+		return "<nil error>"
+	}
+	return e.E.Error()
+}
+
+func (e Error) Keys() []Value {
+	return []Value{String("message"), String("code")}
+}
+
+func (e Error) At(key Value) Value {
+	switch key {
+	case String("message"):
+		return String(e.Error())
+	case String("code"):
+		return Int(e.Code)
+	}
+	return nil
+}
+
+func (e Error) Set(key, v Value) error {
+	return fmt.Errorf("error values are read-only")
+}
@@ -13,7 +13,20 @@ type Program []expr
 
 // Eval executes an apl program.
 // It can be called in a loop for every line of input.
-func (a *Apl) Eval(p Program) (err error) {
+// A running evaluation can be stopped with Interrupt.
+func (a *Apl) Eval(p Program) error {
+	defer a.intr.enter()()
+	return a.eval(p)
+}
+
+func (a *Apl) eval(p Program) error {
+	_, err := a.evalLast(p, false)
+	return err
+}
+
+// evalLast evaluates the program and writes the results of non-assignments.
+// If last is true, the value of the last expression is returned instead of written.
+func (a *Apl) evalLast(p Program, last bool) (val Value, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = fmt.Errorf("panic: %s\n%s", r, string(debug.Stack()))
@@ -30,13 +43,19 @@ func (a *Apl) Eval(p Program) (err error) {
 		default:
 			fmt.Fprintln(a.stdout, val.String(a.Format))
 		}
+		a.flush()
 	}
 
-	var val Value
-	for _, expr := range p {
+	for i, expr := range p {
+		if err := a.Interrupted(); err != nil {
+			return nil, err
+		}
 		val, err = expr.Eval(a)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if last && i == len(p)-1 {
+			return val, nil
 		}
 		if isAssignment(expr) == false {
 			switch v := val.(type) {
@@ -57,7 +76,19 @@ func (a *Apl) Eval(p Program) (err error) {
 			}
 		}
 	}
-	return nil
+	return nil, nil
+}
+
+// flush flushes the output writer, if it is buffered.
+// It is called after each statement, such that the output of
+// long running programs shows up incrementally.
+func (a *Apl) flush() {
+	switch w := a.stdout.writer().(type) {
+	case interface{ Flush() error }:
+		w.Flush()
+	case interface{ Flush() }:
+		w.Flush()
+	}
 }
 
 // EvalProgram evaluates all expressions in the program and returns the values.
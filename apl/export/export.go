@@ -0,0 +1,162 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/ktye/iv/apl"
+	aplio "github.com/ktye/iv/apl/io"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// PNG writes a numeric matrix as a grayscale png image.
+// Rows of the matrix are image rows.
+// Values are scaled linearly from the minimum (black) to the maximum (white).
+// A constant matrix is black.
+func PNG(w io.Writer, R apl.Value) error {
+	v, shape, err := table(R)
+	if err != nil {
+		return err
+	}
+	x := make([]float64, len(v))
+	min, max := math.Inf(1), math.Inf(-1)
+	for i := range v {
+		f, ok := float(v[i])
+		if ok == false {
+			return fmt.Errorf("argument must be real: %T", v[i])
+		}
+		x[i] = f
+		min = math.Min(min, f)
+		max = math.Max(max, f)
+	}
+	if len(x) == 0 {
+		return fmt.Errorf("cannot write an empty image")
+	}
+
+	m := image.NewGray(image.Rect(0, 0, shape[1], shape[0]))
+	for i := range x {
+		var g uint8
+		if max > min {
+			g = uint8(math.Round(255 * (x[i] - min) / (max - min)))
+		}
+		m.SetGray(i%shape[1], i/shape[1], color.Gray{g})
+	}
+	return png.Encode(w, m)
+}
+
+// CSV writes a vector or matrix as comma separated values.
+// A vector is written as a single row.
+// Numbers are written in go syntax, other values are formatted with f.
+func CSV(w io.Writer, f apl.Format, R apl.Value) error {
+	v, shape, err := table(R)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	for i := 0; i < shape[0]; i++ {
+		rec := make([]string, shape[1])
+		for k := range rec {
+			e := v[i*shape[1]+k]
+			switch n := e.(type) {
+			case apl.Bool, apl.Int:
+				x, _ := float(n)
+				rec[k] = strconv.Itoa(int(x))
+			case numbers.Float:
+				rec[k] = strconv.FormatFloat(float64(n), 'g', -1, 64)
+			case apl.String:
+				rec[k] = string(n)
+			default:
+				rec[k] = e.String(f)
+			}
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func pngfile(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	var b bytes.Buffer
+	if err := PNG(&b, R); err != nil {
+		return nil, fmt.Errorf("export png: %s", err)
+	}
+	return write(L, b.Bytes(), "png")
+}
+
+func csvfile(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	var b bytes.Buffer
+	if err := CSV(&b, a.Format, R); err != nil {
+		return nil, fmt.Errorf("export csv: %s", err)
+	}
+	return write(L, b.Bytes(), "csv")
+}
+
+// write writes the data to the file named by L.
+func write(L apl.Value, data []byte, name string) (apl.Value, error) {
+	file, ok := L.(apl.String)
+	if ok == false {
+		return nil, fmt.Errorf("export %s: left argument must be a file name", name)
+	}
+	wc, err := aplio.Create(string(file))
+	if err != nil {
+		return nil, fmt.Errorf("export %s: %s", name, err)
+	}
+	n, err := wc.Write(data)
+	if err != nil {
+		wc.Close()
+		return nil, fmt.Errorf("export %s: %s", name, err)
+	}
+	if err := wc.Close(); err != nil {
+		return nil, fmt.Errorf("export %s: %s", name, err)
+	}
+	return apl.Int(n), nil
+}
+
+// table returns the values of a vector or matrix and the matrix shape.
+// A scalar or vector is a single row.
+func table(R apl.Value) ([]apl.Value, []int, error) {
+	if _, ok := R.(apl.EmptyArray); ok {
+		return nil, []int{0, 0}, nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return []apl.Value{R}, []int{1, 1}, nil
+	}
+	shape := ar.Shape()
+	switch len(shape) {
+	case 1:
+		shape = []int{1, shape[0]}
+	case 2:
+	default:
+		return nil, nil, fmt.Errorf("argument must be a vector or matrix: rank %d", len(shape))
+	}
+	v := make([]apl.Value, ar.Size())
+	for i := range v {
+		v[i] = ar.At(i)
+	}
+	return v, shape, nil
+}
+
+func float(v apl.Value) (float64, bool) {
+	switch n := v.(type) {
+	case apl.Bool:
+		if n {
+			return 1, true
+		}
+		return 0, true
+	case apl.Int:
+		return float64(n), true
+	case numbers.Float:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"bytes"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+	aplio "github.com/ktye/iv/apl/io"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/primitives"
+)
+
+func newApl() *apl.Apl {
+	a := apl.New(ioutil.Discard)
+	numbers.Register(a)
+	primitives.Register(a)
+	Register(a, "")
+	return a
+}
+
+func eval(t *testing.T, a *apl.Apl, s string) apl.Value {
+	p, err := a.Parse(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := a.EvalProgram(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v[0]
+}
+
+func TestPNG(t *testing.T) {
+	a := newApl()
+	var b bytes.Buffer
+	if err := PNG(&b, eval(t, a, "2 3⍴¯1 0 1 2 3 3")); err != nil {
+		t.Fatal(err)
+	}
+	if h := "\x89PNG\r\n\x1a\n"; strings.HasPrefix(b.String(), h) == false {
+		t.Fatalf("png header is missing: %q", b.String()[:8])
+	}
+	m, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := m.Bounds(); r.Dx() != 3 || r.Dy() != 2 {
+		t.Fatalf("wrong image size: %v", r)
+	}
+	exp := []uint32{0, 64, 128, 191, 255, 255}
+	for i, e := range exp {
+		g, _, _, _ := m.At(i%3, i/3).RGBA()
+		if g>>8 != e {
+			t.Fatalf("pixel %d: expected %d, got %d", i, e, g>>8)
+		}
+	}
+
+	if err := PNG(&b, eval(t, a, "2 2⍴'abcd'")); err == nil {
+		t.Fatal("expected error for a character matrix")
+	}
+	if err := PNG(&b, eval(t, a, "2 2 2⍴1")); err == nil {
+		t.Fatal("expected error for rank 3")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	a := newApl()
+	testCases := []struct {
+		in, exp string
+	}{
+		{"2 3⍴⍳6", "1,2,3\n4,5,6\n"},
+		{"1 2.5 ¯3", "1,2.5,-3\n"},
+		{"3 1⍴1 0 1=1", "1\n0\n1\n"},
+		{"2 2⍴\"a\" \"b,c\" 1 2", "a,\"b,c\"\n1,2\n"},
+		{"⍳0", ""},
+	}
+	for _, tc := range testCases {
+		var b bytes.Buffer
+		if err := CSV(&b, a.Format, eval(t, a, tc.in)); err != nil {
+			t.Fatalf("%s: %s", tc.in, err)
+		}
+		if got := b.String(); got != tc.exp {
+			t.Fatalf("%s: expected %q, got %q", tc.in, tc.exp, got)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	a := newApl()
+	if err := a.ParseAndEval(`"/nofs/x.csv" export→csv 1 2`); err == nil {
+		t.Fatal("expected error without a mounted file system")
+	}
+
+	aplio.Register(a, "")
+	if err := a.ParseAndEval(`"/readonly/" io→mount "` + filepath.ToSlash(dir) + `"`); err != nil {
+		t.Fatal(err)
+	}
+	defer aplio.Umount("/readonly/")
+	if err := a.ParseAndEval(`"/readonly/x.csv" export→csv 1 2`); err == nil {
+		t.Fatal("expected error for a read-only mount point")
+	}
+
+	if err := a.ParseAndEval(`"/export/" io→mount "rw://` + filepath.ToSlash(dir) + `/jail"`); err == nil {
+		t.Fatal("expected error for a missing directory")
+	}
+	if err := os.Mkdir(filepath.Join(dir, "jail"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ParseAndEval(`"/export/" io→mount "rw://` + filepath.ToSlash(dir) + `/jail"`); err != nil {
+		t.Fatal(err)
+	}
+	defer aplio.Umount("/export/")
+	if err := a.ParseAndEval(`"/export/../escaped.csv" export→csv 1 2`); err == nil {
+		t.Fatal("expected error for a path outside of the mount point")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escaped.csv")); err == nil {
+		t.Fatal("file was written outside of the mount point")
+	}
+	if err := a.ParseAndEval(`"/export/x.csv" export→csv 2 2⍴⍳4`); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "jail", "x.csv")); err != nil {
+		t.Fatal(err)
+	} else if s := string(b); s != "1,2\n3,4\n" {
+		t.Fatalf("wrong file content: %q", s)
+	}
+	if err := a.ParseAndEval(`"/export/x.png" export→png 2 2⍴⍳4`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "jail", "x.png")); err != nil {
+		t.Fatal(err)
+	}
+}
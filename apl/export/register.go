@@ -0,0 +1,27 @@
+// Package export writes arrays to files for visualization.
+//
+// Files are created through the file system of package io.
+// Without io, or if the mount point is not writable, writing fails.
+// An os directory must be mounted with the rw:// prefix to be writable.
+//
+//	`/file.png export→png R     grayscale image of a numeric matrix
+//	`/file.csv export→csv R     comma separated values
+//
+// The functions return the number of bytes written.
+package export
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the export package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "export"
+	}
+	pkg := map[string]apl.Value{
+		"png": apl.ToFunction(pngfile),
+		"csv": apl.ToFunction(csvfile),
+	}
+	a.RegisterPackage(name, pkg)
+}
@@ -49,11 +49,113 @@ func (a *Apl) SetPP(R Value) error {
 	return fmt.Errorf("illegal type for PP: %T", R)
 }
 
+// SetElide is called when a value is assigned to Quad-ELIDE.
+// R must be a non-negative integer.
+// If it is 0, arrays are printed in full.
+// Otherwise arrays with more than R elements are printed compactly:
+// each axis longer than R shows only its head and tail, separated by ...
+func (a *Apl) SetElide(R Value) error {
+	if n, ok := R.(Number); ok {
+		if i, ok := n.ToIndex(); ok && i >= 0 {
+			a.Format.Elide = i
+			return nil
+		}
+	}
+	return fmt.Errorf("illegal value for ELIDE: %s", R.String(a.Format))
+}
+
+// setSeparator is called when a value is assigned to Quad-SEP or Quad-EOL.
+// R must be a string or a character vector.
+// The empty array resets to the default.
+func (a *Apl) setSeparator(sep *string, R Value) error {
+	switch v := R.(type) {
+	case EmptyArray:
+		*sep = ""
+		return nil
+	case String:
+		*sep = string(v)
+		return nil
+	case StringArray:
+		if len(v.Dims) == 1 {
+			*sep = strings.Join(v.Strings, "")
+			return nil
+		}
+	}
+	return fmt.Errorf("illegal separator: %s", R.String(a.Format))
+}
+
+// SetDecimal is called when a value is assigned to Quad-DEC.
+// It sets the decimal separator for number formatting and parsing.
+// R must be "," or ".". The empty array resets to the default period.
+func (a *Apl) SetDecimal(R Value) error {
+	var dec string
+	if err := a.setSeparator(&dec, R); err != nil {
+		return err
+	}
+	switch dec {
+	case "", ".":
+		a.Format.Dec = ""
+		a.Scanner.SetDecimal('.')
+	case ",":
+		a.Format.Dec = ","
+		a.Scanner.SetDecimal(',')
+	default:
+		return fmt.Errorf("illegal decimal separator: %s", R.String(a.Format))
+	}
+	return nil
+}
+
+// SetRat is called when a value is assigned to Quad-RAT.
+// If R is 1 (the default), rationals are printed as exact fractions (3r4),
+// if it is 0, they are printed as decimal approximations (0.75).
+func (a *Apl) SetRat(R Value) error {
+	if n, ok := R.(Number); ok {
+		if b, ok := a.Tower.ToBool(n); ok {
+			a.Format.RatDec = !bool(b)
+			return nil
+		}
+	}
+	return fmt.Errorf("illegal value for RAT: %s", R.String(a.Format))
+}
+
+// GroupDigits inserts the thousands separator f.Group into the integer part of
+// a formatted number, e.g. 1234567.5 becomes 1,234,567.5.
+// It is only applied for PP >= 0, other formats are intended to be parsed.
+func GroupDigits(f Format, s string) string {
+	if f.Group == "" || f.PP < 0 {
+		return s
+	}
+	isdigit := func(r rune) bool { return r >= '0' && r <= '9' }
+	i := strings.IndexFunc(s, isdigit)
+	if i < 0 {
+		return s
+	}
+	n := strings.IndexFunc(s[i:], func(r rune) bool { return isdigit(r) == false })
+	if n < 0 {
+		n = len(s) - i
+	}
+	if n <= 3 {
+		return s
+	}
+	var b strings.Builder
+	b.WriteString(s[:i])
+	for k := 0; k < n; k++ {
+		if k > 0 && (n-k)%3 == 0 {
+			b.WriteString(f.Group)
+		}
+		b.WriteByte(s[i+k])
+	}
+	b.WriteString(s[i+n:])
+	return b.String()
+}
+
 // ArrayString can be used by an array implementation.
 // It formats an n-dimensional array using a tabwriter for PP>=-1.
 // Each dimension is terminated by k newlines, where k is the dimension index.
 // For PP==-2, it uses a single line json notation with nested brackets and
 // for PP==-3, it formats in a single line matlab syntax (rank <= 2).
+// If f.Sep or f.Eol is set, elements are not aligned but joined by the separators.
+// Otherwise, if f.Elide is set and the array is larger, the middle parts are elided.
 func ArrayString(f Format, v Array) string {
 	if f.PP == -2 {
 		return jsonArray(f, v)
@@ -63,6 +165,10 @@ func ArrayString(f Format, v Array) string {
 	shape := v.Shape()
 	if len(shape) == 0 {
 		return ""
+	} else if f.Sep != "" || f.Eol != "" {
+		return separatedString(f, v)
+	} else if f.Elide > 0 && Prod(shape) > f.Elide {
+		return elidedString(f, v)
 	} else if len(shape) == 1 {
 		s := make([]string, shape[0])
 		for i := 0; i < shape[0]; i++ {
@@ -109,6 +215,116 @@ func ArrayString(f Format, v Array) string {
 	return s
 }
 
+// separatedString formats an array with custom separators.
+// Elements are separated by f.Sep (default space) and rows are terminated by f.Eol (default newline).
+// Higher dimensions are terminated by k row terminators, where k is the dimension index.
+func separatedString(f Format, v Array) string {
+	sep, eol := f.Sep, f.Eol
+	if sep == "" {
+		sep = " "
+	}
+	if eol == "" {
+		eol = "\n"
+	}
+	shape := v.Shape()
+	cols := shape[len(shape)-1]
+	if cols == 0 {
+		return ""
+	}
+	var b strings.Builder
+	size := Prod(shape)
+	for i := 0; i < size; i++ {
+		b.WriteString(v.At(i).String(f))
+		if i == size-1 {
+			break
+		} else if (i+1)%cols != 0 {
+			b.WriteString(sep)
+			continue
+		}
+		// Count the number of axes that wrap after this element.
+		n, k := i+1, 0
+		for d := len(shape) - 1; d > 0 && n%shape[d] == 0; d-- {
+			n /= shape[d]
+			k++
+		}
+		b.WriteString(strings.Repeat(eol, k))
+	}
+	return b.String()
+}
+
+// elidedString formats a large array compactly.
+// Each axis that is longer than f.Elide is reduced to its head and tail.
+// Elided elements are replaced by a single ... within a row
+// and by a line containing ... for higher axes.
+func elidedString(f Format, v Array) string {
+	shape := v.Shape()
+	head, tail := f.Elide-f.Elide/2, f.Elide/2
+	skip := func(n, j int) bool {
+		return n > f.Elide && j == head
+	}
+	if len(shape) == 1 {
+		n := shape[0]
+		var s []string
+		for j := 0; j < n; j++ {
+			if skip(n, j) {
+				s = append(s, "...")
+				j = n - tail - 1
+				continue
+			}
+			s = append(s, v.At(j).String(f))
+		}
+		return strings.Join(s, " ")
+	}
+
+	// The line for elided rows has empty cells to keep the columns aligned.
+	cols := shape[len(shape)-1]
+	if cols > f.Elide {
+		cols = head + tail + 1
+	}
+	dots := "...\t" + strings.Repeat("\t", cols-1)
+
+	var buf strings.Builder
+	tw := tabwriter.NewWriter(&buf, 1, 0, 1, ' ', tabwriter.AlignRight)
+	var walk func(axis, off int)
+	walk = func(axis, off int) {
+		n := shape[axis]
+		stride := Prod(shape[axis+1:])
+		last := axis == len(shape)-1
+		for j := 0; j < n; j++ {
+			if skip(n, j) {
+				if last {
+					fmt.Fprint(tw, "...\t")
+				} else {
+					fmt.Fprintln(tw, dots)
+					if axis < len(shape)-2 {
+						fmt.Fprintln(tw)
+					}
+				}
+				j = n - tail - 1
+				continue
+			}
+			if last {
+				fmt.Fprintf(tw, "%s\t", v.At(off+j).String(f))
+			} else {
+				walk(axis+1, off+j*stride)
+				if axis < len(shape)-2 && j < n-1 {
+					fmt.Fprintln(tw)
+				}
+			}
+		}
+		if last {
+			fmt.Fprintln(tw)
+		}
+	}
+	walk(0, 0)
+	tw.Flush()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
 // stringArray converts the array to a string array of the same shape.
 // All elements are printed with the current PP.
 func stringArray(f Format, v Array) StringArray {
@@ -0,0 +1,28 @@
+// Package fmt formats time stamps and durations as strings.
+//
+//	fmt→time R          format the time R as 2006-01-02 15:04:05
+//	L fmt→time R        format the time R with the go layout L
+//	fmt→duration R      format the duration R as 2h 48m 46.8s
+//	L fmt→duration R    truncate the duration to the unit L (h m s ms us ns)
+//
+// Examples:
+//	"Jan 2, 2006" fmt→time 2018.12.23    Dec 23, 2018
+//	"m" fmt→duration 4m×42.195           2h 48m
+// Arrays are formatted element-wise and return an array of strings.
+package fmt
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the fmt package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "fmt"
+	}
+	pkg := map[string]apl.Value{
+		"time":     apl.ToFunction(formatTime),
+		"duration": apl.ToFunction(formatDuration),
+	}
+	a.RegisterPackage(name, pkg)
+}
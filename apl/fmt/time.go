@@ -0,0 +1,84 @@
+package fmt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+func formatTime(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	layout, err := layoutArg("2006-01-02 15:04:05", L)
+	if err != nil {
+		return nil, fmt.Errorf("fmt time: %s", err)
+	}
+	v, err := each(R, func(t numbers.Time) (string, error) {
+		if _, ok := t.Duration(); ok {
+			return "", fmt.Errorf("argument is a duration")
+		}
+		return time.Time(t).Format(layout), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fmt time: %s", err)
+	}
+	return v, nil
+}
+
+func formatDuration(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	unit, err := layoutArg("", L)
+	if err != nil {
+		return nil, fmt.Errorf("fmt duration: %s", err)
+	}
+	v, err := each(R, func(t numbers.Time) (string, error) {
+		d, ok := t.Duration()
+		if ok == false {
+			return "", fmt.Errorf("argument is not a duration")
+		}
+		return numbers.FormatDuration(d, unit)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fmt duration: %s", err)
+	}
+	return v, nil
+}
+
+// layoutArg returns the string L or the default, if L is nil.
+func layoutArg(def string, L apl.Value) (string, error) {
+	if L == nil {
+		return def, nil
+	}
+	s, ok := L.(apl.String)
+	if ok == false {
+		return "", fmt.Errorf("L must be a string: %T", L)
+	}
+	return string(s), nil
+}
+
+// each applies f to a time or to all elements of an array of times.
+func each(R apl.Value, f func(numbers.Time) (string, error)) (apl.Value, error) {
+	if t, ok := R.(numbers.Time); ok {
+		s, err := f(t)
+		if err != nil {
+			return nil, err
+		}
+		return apl.String(s), nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return nil, fmt.Errorf("argument must be a time: %T", R)
+	}
+	res := apl.StringArray{Dims: apl.CopyShape(ar), Strings: make([]string, ar.Size())}
+	for i := range res.Strings {
+		t, ok := ar.At(i).(numbers.Time)
+		if ok == false {
+			return nil, fmt.Errorf("argument must be a time: %T", ar.At(i))
+		}
+		s, err := f(t)
+		if err != nil {
+			return nil, err
+		}
+		res.Strings[i] = s
+	}
+	return res, nil
+}
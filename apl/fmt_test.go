@@ -103,3 +103,50 @@ func TestScanRankArray(t *testing.T) {
 	next:
 	}
 }
+
+func TestArraySeparator(t *testing.T) {
+	testCases := []struct {
+		sep, eol string
+		shape    []int
+		out      string
+	}{
+		{"\t", "", []int{3}, "1\t2\t3"},
+		{"\t", "", []int{2, 3}, "1\t2\t3\n4\t5\t6"},
+		{"\t", "\r\n", []int{2, 3}, "1\t2\t3\r\n4\t5\t6"},
+		{"", ";", []int{3, 2}, "1 2;3 4;5 6"},
+		{",", ";", []int{2, 2, 2}, "1,2;3,4;;5,6;7,8"},
+		{",", "", []int{2, 0}, ""},
+	}
+	for _, tc := range testCases {
+		v := IntArray{Dims: tc.shape, Ints: make([]int, Prod(tc.shape))}
+		for i := range v.Ints {
+			v.Ints[i] = 1 + i
+		}
+		if s := ArrayString(Format{Sep: tc.sep, Eol: tc.eol}, v); s != tc.out {
+			t.Fatalf("%q %q %v: expected %q, got %q", tc.sep, tc.eol, tc.shape, tc.out, s)
+		}
+	}
+}
+
+func TestDictTable(t *testing.T) {
+	col := func(v ...int) Value { return IntArray{Dims: []int{len(v)}, Ints: v} }
+	testCases := []struct {
+		values []Value
+		out    string
+	}{
+		{[]Value{col(1, 2, 3), col(10, 20, 30), col(-1, 200, 3)}, "id qty delta\n1  10  ¯1\n2  20  200\n3  30  3"},
+		{[]Value{col(1, 2, 3), col(10, 20), col(5)}, "id:    1 2 3\nqty:   10 20\ndelta: 5"},
+		{[]Value{col(1), col(2), col(3)}, "id:    1\nqty:   2\ndelta: 3"},
+		{[]Value{col(1, 2), Int(3), col(4, 5)}, "id:    1 2\nqty:   3\ndelta: 4 5"},
+	}
+	for _, tc := range testCases {
+		d := &Dict{M: make(map[Value]Value)}
+		for i, k := range []String{"id", "qty", "delta"} {
+			d.K = append(d.K, k)
+			d.M[k] = tc.values[i]
+		}
+		if s := d.String(Format{}); s != tc.out {
+			t.Fatalf("expected:\n%s\ngot:\n%s", tc.out, s)
+		}
+	}
+}
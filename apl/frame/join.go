@@ -0,0 +1,137 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/operators"
+)
+
+// join is the inner join of two frames on a key column.
+// Only rows with a key present in both frames are kept.
+func join(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	d, err := joinFrames(a, L, R, false)
+	if err != nil {
+		return nil, fmt.Errorf("join: %s", err)
+	}
+	return d, nil
+}
+
+// ljoin is the left join of two frames on a key column.
+// All rows of the left frame are kept.
+// Columns of the right frame are ⎕NULL for keys without a match.
+func ljoin(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	d, err := joinFrames(a, L, R, true)
+	if err != nil {
+		return nil, fmt.Errorf("ljoin: %s", err)
+	}
+	return d, nil
+}
+
+// joinFrames aligns the rows of B to the rows of A by looking up
+// the keys of A in the key column of B with index of (⍳).
+// If keys in B are not unique, the first match is used.
+// The result has the columns of A followed by those of B without the key.
+func joinFrames(a *apl.Apl, L, R apl.Value, left bool) (*apl.Dict, error) {
+	if _, ok := L.(apl.String); ok == false {
+		return nil, fmt.Errorf("left argument must be the key column")
+	}
+	l, ok := R.(apl.List)
+	if ok == false || len(l) != 2 {
+		return nil, fmt.Errorf("right argument must be a list of two frames")
+	}
+	A, err := frame(a, l[0])
+	if err != nil {
+		return nil, err
+	}
+	B, err := frame(a, l[1])
+	if err != nil {
+		return nil, err
+	}
+	ka, kb := A.At(L), B.At(L)
+	if ka == nil || kb == nil {
+		return nil, fmt.Errorf("key column does not exist: %s", L.String(a.Format))
+	}
+
+	x, err := apl.Primitive("⍳").Call(a, kb, ka)
+	if err != nil {
+		return nil, err
+	}
+	idx, ok := x.(apl.Array)
+	if ok == false {
+		return nil, fmt.Errorf("unexpected index type: %T", x)
+	}
+	nb := kb.(apl.Array).Size()
+	rows := make([]int, 0, idx.Size())
+	mask := apl.IntArray{Dims: []int{idx.Size()}, Ints: make([]int, idx.Size())}
+	for i := range mask.Ints {
+		n, ok := idx.At(i).(apl.Number).ToIndex()
+		if ok == false {
+			return nil, fmt.Errorf("unexpected index value: %s", idx.At(i).String(a.Format))
+		}
+		n -= a.Origin
+		if n < nb {
+			mask.Ints[i] = 1
+		} else if left == false {
+			continue
+		} else {
+			n = -1
+		}
+		rows = append(rows, n)
+	}
+
+	var d apl.Dict
+	d.M = make(map[apl.Value]apl.Value)
+	for _, k := range A.Keys() {
+		v := A.At(k)
+		if left == false {
+			if v, err = operators.Replicate(a, mask, v, 0); err != nil {
+				return nil, err
+			}
+		}
+		d.K = append(d.K, k.Copy())
+		d.M[k.Copy()] = v.Copy()
+	}
+	for _, k := range B.Keys() {
+		if k == L {
+			continue
+		} else if _, ok := d.M[k]; ok {
+			return nil, fmt.Errorf("duplicate column: %s", k.String(a.Format))
+		}
+		col := B.At(k).(apl.Array)
+		v := apl.MixedArray{Dims: []int{len(rows)}, Values: make([]apl.Value, len(rows))}
+		for i, n := range rows {
+			if n < 0 {
+				v.Values[i] = apl.Null{}
+			} else {
+				v.Values[i] = col.At(n).Copy()
+			}
+		}
+		d.K = append(d.K, k.Copy())
+		d.M[k.Copy()] = a.UnifyArray(v)
+	}
+	return &d, nil
+}
+
+// frame returns v as a dict, if it is a dict of vectors with the same length.
+func frame(a *apl.Apl, v apl.Value) (*apl.Dict, error) {
+	if t, ok := v.(apl.Table); ok {
+		return t.Dict, nil
+	}
+	d, ok := v.(*apl.Dict)
+	if ok == false {
+		return nil, fmt.Errorf("argument must be a dict: %T", v)
+	}
+	n := -1
+	for _, k := range d.Keys() {
+		ar, ok := d.At(k).(apl.Array)
+		if ok == false || len(ar.Shape()) != 1 {
+			return nil, fmt.Errorf("column %s is not a vector", k.String(a.Format))
+		}
+		if n >= 0 && ar.Size() != n {
+			return nil, fmt.Errorf("columns have different lengths")
+		}
+		n = ar.Size()
+	}
+	return d, nil
+}
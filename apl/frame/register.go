@@ -0,0 +1,24 @@
+// Package frame provides operations on dataframe-like dicts.
+//
+// A frame is a dict whose values are vectors of the same length.
+// Each value is a column, the keys are the column names.
+//
+//	`id frame→join (A;B;)    inner join of A and B on column id
+//	`id frame→ljoin (A;B;)   left join, missing values are ⎕NULL
+package frame
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the frame package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "frame"
+	}
+	pkg := map[string]apl.Value{
+		"join":  apl.ToFunction(join),
+		"ljoin": apl.ToFunction(ljoin),
+	}
+	a.RegisterPackage(name, pkg)
+}
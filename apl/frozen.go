@@ -0,0 +1,92 @@
+package apl
+
+import "sync"
+
+// Frozen wraps a value and marks it as immutable.
+// It is created by a→f from package a, see Freeze.
+//
+// Reading a variable that holds a frozen value returns the wrapped value,
+// but selective, indexed or modified assignment into it fails:
+//
+//	X←a→f 1 2 3 ⋄ X+1   ⍝ 2 3 4
+//	X[1]←5              ⍝ fails: value is frozen
+//	X←5                 ⍝ plain assignment replaces the value
+//
+// A frozen value within a list or a dict is protected as well.
+//
+// Assigning a frozen variable to another name gives a mutable copy.
+// Namespaces and xgo values are copied by reference,
+// they are copied when they are written to, see Thaw.
+type Frozen struct {
+	Value
+}
+
+func (f Frozen) Copy() Value             { return Frozen{f.Value.Copy()} }
+func (f Frozen) DeepCopy(c Copies) Value { return Frozen{c.Copy(f.Value)} }
+
+// Unfreeze returns the wrapped value of a Frozen or v itself.
+func Unfreeze(v Value) Value {
+	if f, ok := v.(Frozen); ok {
+		return f.Value
+	}
+	return v
+}
+
+// Referencer is implemented by values that are copied by reference, such as xgo values.
+// Ref returns a comparable identity of the referenced data, or nil.
+type Referencer interface {
+	Ref() interface{}
+}
+
+// ref returns the identity of a reference value or nil.
+func ref(v Value) interface{} {
+	switch x := v.(type) {
+	case *Namespace:
+		return x
+	case Referencer:
+		return x.Ref()
+	}
+	return nil
+}
+
+// frozenSet holds the references that are part of frozen values.
+// Forks share the set, as they share the referenced values.
+type frozenSet struct {
+	sync.Mutex
+	m map[interface{}]Value
+}
+
+// Freeze returns v as a Frozen value.
+// The value is deep copied and the references of the copy are marked as frozen.
+func (a *Apl) Freeze(v Value) Frozen {
+	if f, ok := v.(Frozen); ok {
+		return f
+	}
+	c := make(Copies)
+	f := Frozen{c.Copy(v)}
+	a.frozen.Lock()
+	defer a.frozen.Unlock()
+	for _, r := range c {
+		if k := ref(r); k != nil {
+			a.frozen.m[k] = r
+		}
+	}
+	return f
+}
+
+// Thaw returns a deep copy of v, if v is a reference that is part of a frozen value.
+// Otherwise it returns v and false.
+// It is called before writing to a value, that may be shared with a frozen value.
+func (a *Apl) Thaw(v Value) (Value, bool) {
+	k := ref(v)
+	if k == nil {
+		return v, false
+	}
+	a.frozen.Lock()
+	_, ok := a.frozen.m[k]
+	a.frozen.Unlock()
+	if ok == false {
+		return v, false
+	}
+	return DeepCopy(v), true
+}
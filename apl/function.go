@@ -56,6 +56,12 @@ func (f *function) Eval(a *Apl) (Value, error) {
 		}
 	}
 
+	// A frozen value is only protected within a variable.
+	// Functions receive the wrapped value.
+	if d, ok := f.Function.(*derived); ok == false || d.op != "←" {
+		l, r = Unfreeze(l), Unfreeze(r)
+	}
+
 	// Special case: the last function in a selective assignment uses Select instead of Call.
 	if _, ok := f.right.(numVar); ok && f.selection {
 		if d, ok := f.Function.(*derived); ok == true {
@@ -123,6 +129,7 @@ func (p Primitive) Copy() Value { return p }
 // they are tested in reverse registration order, until the first one takes the
 // responsibility.
 func (p Primitive) Call(a *Apl, L, R Value) (Value, error) {
+	L, R = Unfreeze(L), Unfreeze(R)
 	if handles := a.primitives[p]; handles == nil {
 		return nil, fmt.Errorf("primitive function %s does not exist", p)
 	} else {
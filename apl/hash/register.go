@@ -0,0 +1,43 @@
+// Package hash provides message digests of strings.
+//
+// The argument is a string scalar or a character vector.
+// It is hashed as UTF-8, the result is a hex encoded string.
+//
+//	hash→md5 'alpha'
+//	hash→sha256 'alpha'
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the hash package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "hash"
+	}
+	pkg := map[string]apl.Value{
+		"md5":    digest("md5", func(b []byte) []byte { h := md5.Sum(b); return h[:] }),
+		"sha256": digest("sha256", func(b []byte) []byte { h := sha256.Sum256(b); return h[:] }),
+	}
+	a.RegisterPackage(name, pkg)
+}
+
+// digest returns a monadic function that applies the hash function f.
+func digest(name string, f func([]byte) []byte) apl.ToFunction {
+	return apl.ToFunction(func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		if L != nil {
+			return nil, fmt.Errorf("hash %s must be called monadically", name)
+		}
+		s, err := apl.JoinString(R)
+		if err != nil {
+			return nil, fmt.Errorf("hash %s: %s", name, err)
+		}
+		return apl.String(hex.EncodeToString(f([]byte(s)))), nil
+	})
+}
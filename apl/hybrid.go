@@ -0,0 +1,39 @@
+package apl
+
+import "fmt"
+
+// hybrid is one of the operators / ⌿ \ ⍀ used as a function.
+//
+// The symbols are registered as monadic operators with an array as the left operand.
+// If there is nothing on the left within the same expression, they cannot
+// be operators and the parser converts them into a hybrid function:
+//	(/∘⊢)         ⍝ compose replicate with right tack
+//	1 0 1 (/) R   ⍝ same as 1 0 1/R
+// The left argument of the function becomes the operand of the operator.
+type hybrid string
+
+// isHybrid returns if the symbol can be used as both an operator and a function.
+func isHybrid(s string) bool {
+	switch s {
+	case "/", "⌿", `\`, "⍀":
+		return true
+	}
+	return false
+}
+
+func (h hybrid) Eval(a *Apl) (Value, error) { return h, nil }
+func (h hybrid) String(f Format) string     { return string(h) }
+func (h hybrid) Copy() Value                { return h }
+
+// Call applies the operator with L as the left operand to R.
+func (h hybrid) Call(a *Apl, L, R Value) (Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("function %s requires a left argument", string(h))
+	}
+	for _, op := range a.operators[string(h)] {
+		if LO, RO, ok := op.To(a, L, nil); ok {
+			return op.Derived(a, LO, RO).Call(a, nil, R)
+		}
+	}
+	return nil, fmt.Errorf("function %s: cannot handle left argument %T", string(h), L)
+}
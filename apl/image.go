@@ -41,6 +41,25 @@ func (i Image) String(f Format) string {
 }
 func (i Image) Copy() Value { return i } // Image is copied by reference.
 
+// DeepCopy copies the pixels of RGBA and paletted images.
+// Other image types are returned unchanged.
+func (i Image) DeepCopy(_ Copies) Value {
+	r := Image{Image: i.Image, Dims: make([]int, len(i.Dims))}
+	copy(r.Dims, i.Dims)
+	switch m := i.Image.(type) {
+	case *image.RGBA:
+		c := *m
+		c.Pix = append([]uint8(nil), m.Pix...)
+		r.Image = &c
+	case *image.Paletted:
+		c := *m
+		c.Pix = append([]uint8(nil), m.Pix...)
+		c.Palette = append(color.Palette(nil), m.Palette...)
+		r.Image = &c
+	}
+	return r
+}
+
 func (i Image) At(k int) Value {
 	ic, idx := NewIdxConverter(i.Dims)
 	ic.Indexes(k, idx)
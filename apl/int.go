@@ -3,6 +3,7 @@ package apl
 import (
 	"fmt"
 	"math/big"
+	"math/bits"
 	"reflect"
 	"strconv"
 	"strings"
@@ -38,6 +39,7 @@ func (i Int) String(f Format) string {
 	s := fmt.Sprintf(format, i)
 	if minus == false {
 		s = strings.Replace(s, "-", "¯", 1)
+		s = GroupDigits(f, s)
 	}
 	return s
 }
@@ -208,3 +210,8 @@ func (L Int) Gcd(R Value) (Value, bool) {
 	r := big.NewInt(int64(R.(Int)))
 	return Int(big.NewInt(0).GCD(nil, nil, l, r).Int64()), true
 }
+
+// PopCount returns the number of set bits in the 64 bit two's complement representation.
+func (i Int) PopCount() (int, bool) {
+	return bits.OnesCount64(uint64(i)), true
+}
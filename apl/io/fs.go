@@ -44,7 +44,11 @@ func (o fs) String() string {
 }
 
 func (o fs) Open(name, mpt string) (io.ReadCloser, error) {
-	f, err := os.Open(o.path(name))
+	p, err := o.path(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
 	if err != nil {
 		return nil, err
 	}
@@ -72,8 +76,37 @@ func (o fs) Open(name, mpt string) (io.ReadCloser, error) {
 	return ioutil.NopCloser(strings.NewReader(strings.Join(names, "\n"))), nil
 }
 
-func (o fs) path(name string) string {
-	return filepath.Join(string(o), filepath.FromSlash(name))
+// path returns the os path of name below the root directory.
+// Names that resolve outside of the root are rejected.
+func (o fs) path(name string) (string, error) {
+	root := filepath.Clean(string(o))
+	p := filepath.Join(root, filepath.FromSlash(name))
+	if rel, err := filepath.Rel(root, p); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", &os.PathError{
+			Op:   "open",
+			Path: name,
+			Err:  fmt.Errorf("path is outside of the mount point"),
+		}
+	}
+	return p, nil
+}
+
+// rwfs is an os file system that is mounted writable with the rw:// prefix.
+type rwfs struct {
+	fs
+}
+
+func (o rwfs) String() string {
+	return "rw://" + string(o.fs)
+}
+
+// Write creates or truncates a file in the os file system.
+func (o rwfs) Write(name string) (io.WriteCloser, error) {
+	p, err := o.path(name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Create(p)
 }
 
 // Mtab is the mounting table.
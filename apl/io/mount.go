@@ -16,6 +16,7 @@ import (
 // R may contain a protocol suffix, such as zip:// that is matched against
 // known file systems.
 // If no protocol can be matched, R is considered to be an os path.
+// An os path is mounted read-only, unless it is prefixed with rw://.
 //
 // The special file "." can be used, which is always the current working directory.
 func mount(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
@@ -61,9 +62,22 @@ func mount(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 		}
 	}
 
+	// An os path is writable only if it is requested explicitly.
+	rw := false
+	if strings.HasPrefix(src, "rw://") {
+		rw = true
+		src = strings.TrimPrefix(src, "rw://")
+	}
+	osfs := func(dir string) FileSystem {
+		if rw {
+			return rwfs{fs(dir)}
+		}
+		return fs(dir)
+	}
+
 	// Special case, "." remains always relative.
 	if src == "." {
-		if err := Mount(mpt, fs(".")); err != nil {
+		if err := Mount(mpt, osfs(".")); err != nil {
 			return nil, err
 		}
 		return apl.EmptyArray{}, nil
@@ -81,7 +95,7 @@ func mount(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := Mount(mpt, fs(abs)); err != nil {
+	if err := Mount(mpt, osfs(abs)); err != nil {
 		return nil, err
 	}
 	return apl.EmptyArray{}, nil
@@ -67,11 +67,16 @@ func exec(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 		if err != nil {
 			return nil, err
 		}
+		if rw, ok := fsys.(rwfs); ok {
+			fsys = rw.fs
+		}
 		if f, ok := fsys.(fs); ok == false {
 			return nil, fmt.Errorf("exec: %s: file system is not an os fs: %s", argv[0], fsys.String())
 		} else {
 			relpath := strings.TrimPrefix(argv[0], mpt)
-			argv[0] = f.path(relpath)
+			if argv[0], err = f.path(relpath); err != nil {
+				return nil, err
+			}
 		}
 	}
 
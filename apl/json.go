@@ -0,0 +1,106 @@
+package apl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseJSON parses a json value.
+// Objects are converted to a Dict with string keys in the order of the input.
+// Arrays of scalars are returned as uniform arrays if possible,
+// arrays containing arrays or objects as a List.
+// Numbers are parsed by the current tower, booleans are Bool and null is Null.
+func (a *Apl) ParseJSON(s string) (Value, error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	v, err := a.jsonValue(d)
+	if err != nil {
+		return nil, fmt.Errorf("parse json: %s", err)
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, fmt.Errorf("parse json: trailing data")
+	}
+	return v, nil
+}
+
+func (a *Apl) jsonValue(d *json.Decoder) (Value, error) {
+	t, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := t.(type) {
+	case nil:
+		return Null{}, nil
+	case bool:
+		return Bool(v), nil
+	case string:
+		return String(v), nil
+	case json.Number:
+		s := strings.Replace(string(v), "-", "¯", -1)
+		s = strings.Replace(s, "+", "", -1)
+		n, err := a.Tower.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		return n.Number, nil
+	case json.Delim:
+		if v == '{' {
+			return a.jsonObject(d)
+		} else if v == '[' {
+			return a.jsonArray(d)
+		}
+	}
+	return nil, fmt.Errorf("unexpected token: %v", t)
+}
+
+func (a *Apl) jsonObject(d *json.Decoder) (Value, error) {
+	res := &Dict{M: make(map[Value]Value)}
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		k, ok := t.(string)
+		if ok == false {
+			return nil, fmt.Errorf("object key is not a string: %v", t)
+		}
+		v, err := a.jsonValue(d)
+		if err != nil {
+			return nil, err
+		}
+		if err := res.Set(String(k), v); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a *Apl) jsonArray(d *json.Decoder) (Value, error) {
+	var values []Value
+	nested := false
+	for d.More() {
+		v, err := a.jsonValue(d)
+		if err != nil {
+			return nil, err
+		}
+		switch v.(type) {
+		case Array, Object:
+			nested = true
+		}
+		values = append(values, v)
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return EmptyArray{}, nil
+	} else if nested {
+		return List(values), nil
+	}
+	return a.UnifyArray(MixedArray{Dims: []int{len(values)}, Values: values}), nil
+}
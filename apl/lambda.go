@@ -8,8 +8,9 @@ import (
 // Env is the environment of the current lambda function.
 // It contains local variables and a pointer to the parent environment.
 type env struct {
-	parent *env
-	vars   map[string]Value
+	parent   *env
+	vars     map[string]Value
+	readonly bool // packages registered from go cannot be assigned to
 }
 
 // lambda is a function expression in braces {...}.
@@ -34,6 +35,7 @@ func (λ *lambda) Call(a *Apl, l, r Value) (Value, error) {
 	if λ.body == nil {
 		return EmptyArray{}, nil
 	}
+	l, r = Unfreeze(l), Unfreeze(r)
 
 	e := env{
 		vars:   make(map[string]Value),
@@ -45,6 +47,9 @@ func (λ *lambda) Call(a *Apl, l, r Value) (Value, error) {
 
 	e.vars["∇"] = λ
 tail:
+	if err := a.Interrupted(); err != nil {
+		return nil, err
+	}
 	e.vars["⍺"] = l
 	e.vars["⍵"] = r
 
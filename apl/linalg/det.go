@@ -0,0 +1,181 @@
+package linalg
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/primitives"
+)
+
+// det returns the determinant of a square matrix.
+// It uses the LU decomposition of domino.
+// A singular matrix has the determinant 0.
+func det(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("det: cannot be called dyadically")
+	}
+	A, err := matrix(R)
+	if err != nil {
+		return nil, fmt.Errorf("det: %s", err)
+	}
+	n := len(A)
+	if n == 0 {
+		return apl.Int(1), nil
+	} else if len(A[0]) != n {
+		return nil, fmt.Errorf("det: matrix is not square: %d %d", n, len(A[0]))
+	}
+
+	P, err := primitives.LU(a, A)
+	if err == primitives.ErrSingular {
+		return apl.Int(0), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var d apl.Value = apl.Int(1)
+	if odd(P) {
+		d = apl.Int(-1)
+	}
+	for i := 0; i < n; i++ {
+		if d, err = apl.Primitive("×").Call(a, d, A[i][i]); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// rank returns the rank of a matrix by gaussian elimination.
+// Columns without a pivot above the tolerance are skipped.
+// The tolerance of each column is relative to its largest magnitude,
+// such that badly scaled matrices keep their full rank.
+func rank(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("rank: cannot be called dyadically")
+	}
+	A, err := matrix(R)
+	if err != nil {
+		return nil, fmt.Errorf("rank: %s", err)
+	}
+	m := len(A)
+	if m == 0 {
+		return apl.Int(0), nil
+	}
+	n := len(A[0])
+	tol, err := tolerances(a, A)
+	if err != nil {
+		return nil, err
+	}
+
+	r := 0
+	for k := 0; k < n && r < m; k++ {
+		// Find the pivot with the largest magnitude in column k.
+		var max apl.Value = tol[k]
+		p := -1
+		for i := r; i < m; i++ {
+			v, err := apl.Primitive("|").Call(a, nil, A[i][k])
+			if err != nil {
+				return nil, err
+			}
+			if lt, err := apl.Primitive("<").Call(a, max, v); err != nil {
+				return nil, err
+			} else if lt == apl.Bool(true) {
+				max, p = v, i
+			}
+		}
+		if p < 0 {
+			continue
+		}
+		A[r], A[p] = A[p], A[r]
+		for i := r + 1; i < m; i++ {
+			f, err := apl.Primitive("÷").Call(a, A[i][k], A[r][k])
+			if err != nil {
+				return nil, err
+			}
+			for j := k; j < n; j++ {
+				v, err := apl.Primitive("×").Call(a, f, A[r][j])
+				if err != nil {
+					return nil, err
+				}
+				if A[i][j], err = apl.Primitive("-").Call(a, A[i][j], v); err != nil {
+					return nil, err
+				}
+			}
+		}
+		r++
+	}
+	return apl.Int(r), nil
+}
+
+// matrix converts R to a 2d slice of values.
+// A vector is a single column.
+func matrix(R apl.Value) ([][]apl.Value, error) {
+	if _, ok := R.(apl.EmptyArray); ok {
+		return nil, nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return [][]apl.Value{{R}}, nil
+	}
+	shape := ar.Shape()
+	if len(shape) == 1 {
+		shape = []int{shape[0], 1}
+	} else if len(shape) != 2 {
+		return nil, fmt.Errorf("argument must be a matrix: rank %d", len(shape))
+	}
+	A := make([][]apl.Value, shape[0])
+	for i := range A {
+		A[i] = make([]apl.Value, shape[1])
+		for k := range A[i] {
+			A[i][k] = ar.At(i*shape[1] + k).Copy()
+		}
+	}
+	return A, nil
+}
+
+// odd returns if the permutation has an odd number of transpositions.
+func odd(P []int) bool {
+	seen := make([]bool, len(P))
+	n := 0
+	for i := range P {
+		if seen[i] {
+			continue
+		}
+		for k := i; seen[k] == false; k = P[k] {
+			seen[k] = true
+		}
+		n++
+	}
+	return (len(P)-n)%2 == 1
+}
+
+// tolerances returns the threshold for each column, below which a pivot is regarded as 0.
+// It is ⎕CT scaled by the largest magnitude in the column.
+// Exact towers compare against 0.
+func tolerances(a *apl.Apl, A [][]apl.Value) ([]apl.Value, error) {
+	tol := make([]apl.Value, len(A[0]))
+	for k := range tol {
+		tol[k] = apl.Int(0)
+	}
+	ct := a.Tolerance()
+	if a.Tower.Prec == 0 || ct == nil {
+		return tol, nil
+	}
+	for k := range tol {
+		var max apl.Value = apl.Int(0)
+		for i := range A {
+			v, err := apl.Primitive("|").Call(a, nil, A[i][k])
+			if err != nil {
+				return nil, err
+			}
+			if max, err = apl.Primitive("⌈").Call(a, max, v); err != nil {
+				return nil, err
+			}
+		}
+		t, err := apl.Primitive("×").Call(a, ct, max)
+		if err != nil {
+			return nil, err
+		}
+		tol[k] = t
+	}
+	return tol, nil
+}
@@ -0,0 +1,183 @@
+package linalg
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// eig returns the eigenvalues of a real symmetric matrix in ascending order.
+func eig(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("eig: cannot be called dyadically")
+	}
+	w, _, err := jacobi(a, R)
+	if err != nil {
+		return nil, fmt.Errorf("eig: %s", err)
+	}
+	return numbers.FloatArray{Dims: []int{len(w)}, Floats: w}, nil
+}
+
+// eigv returns the eigenvectors of a real symmetric matrix.
+// They are the columns of the result, in the order of the eigenvalues returned by eig.
+// Each eigenvector has unit length and its first non-zero component is positive.
+func eigv(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("eigv: cannot be called dyadically")
+	}
+	_, V, err := jacobi(a, R)
+	if err != nil {
+		return nil, fmt.Errorf("eigv: %s", err)
+	}
+	n := len(V)
+	f := make([]float64, n*n)
+	for i := range V {
+		copy(f[i*n:], V[i])
+	}
+	return numbers.FloatArray{Dims: []int{n, n}, Floats: f}, nil
+}
+
+// jacobi diagonalizes a real symmetric matrix with cyclic Jacobi rotations.
+// It returns the sorted eigenvalues and the eigenvectors as columns of V.
+// The matrix must be symmetric within the comparison tolerance ⎕CT.
+func jacobi(a *apl.Apl, R apl.Value) ([]float64, [][]float64, error) {
+	A, err := floatMatrix(R)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct := 0.0
+	if t, ok := a.Tolerance().(numbers.Float); ok {
+		ct = float64(t)
+	}
+	n := len(A)
+	for i := range A {
+		for k := i + 1; k < n; k++ {
+			x, y := A[i][k], A[k][i]
+			if math.Abs(x-y) > ct*math.Max(math.Abs(x), math.Abs(y)) {
+				return nil, nil, fmt.Errorf("matrix is not symmetric")
+			}
+		}
+	}
+
+	V := make([][]float64, n)
+	for i := range V {
+		V[i] = make([]float64, n)
+		V[i][i] = 1
+	}
+
+	const maxSweeps = 100
+	for sweep := 0; ; sweep++ {
+		off, norm := 0.0, 0.0
+		for i := range A {
+			for k := range A[i] {
+				if i != k {
+					off += A[i][k] * A[i][k]
+				}
+				norm += A[i][k] * A[i][k]
+			}
+		}
+		if off <= 1e-30*norm || off == 0 {
+			break
+		} else if sweep == maxSweeps {
+			return nil, nil, fmt.Errorf("no convergence after %d sweeps", maxSweeps)
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if A[p][q] != 0 {
+					rotate(A, V, p, q)
+				}
+			}
+		}
+	}
+
+	// Sort eigenvalues and permute the eigenvector columns alike.
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, k int) bool { return A[idx[i]][idx[i]] < A[idx[k]][idx[k]] })
+	w := make([]float64, n)
+	S := make([][]float64, n)
+	for i := range S {
+		S[i] = make([]float64, n)
+	}
+	for k, j := range idx {
+		w[k] = A[j][j]
+		sign := 1.0
+		for i := 0; i < n; i++ {
+			if V[i][j] != 0 {
+				if V[i][j] < 0 {
+					sign = -1
+				}
+				break
+			}
+		}
+		for i := 0; i < n; i++ {
+			S[i][k] = sign * V[i][j]
+		}
+	}
+	return w, S, nil
+}
+
+// rotate applies the Jacobi rotation that annihilates A[p][q].
+// The rotation is accumulated in V.
+func rotate(A, V [][]float64, p, q int) {
+	theta := (A[q][q] - A[p][p]) / (2 * A[p][q])
+	t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+	if theta < 0 {
+		t = -t
+	}
+	c := 1 / math.Sqrt(t*t+1)
+	s := t * c
+
+	for k := range A {
+		akp, akq := A[k][p], A[k][q]
+		A[k][p] = c*akp - s*akq
+		A[k][q] = s*akp + c*akq
+	}
+	for k := range A {
+		apk, aqk := A[p][k], A[q][k]
+		A[p][k] = c*apk - s*aqk
+		A[q][k] = s*apk + c*aqk
+	}
+	A[p][q], A[q][p] = 0, 0
+	for k := range V {
+		vkp, vkq := V[k][p], V[k][q]
+		V[k][p] = c*vkp - s*vkq
+		V[k][q] = s*vkp + c*vkq
+	}
+}
+
+// floatMatrix converts a square matrix of real numbers to float64.
+func floatMatrix(R apl.Value) ([][]float64, error) {
+	M, err := matrix(R)
+	if err != nil {
+		return nil, err
+	}
+	n := len(M)
+	if n > 0 && len(M[0]) != n {
+		return nil, fmt.Errorf("matrix is not square: %d %d", n, len(M[0]))
+	}
+	A := make([][]float64, n)
+	for i := range M {
+		A[i] = make([]float64, n)
+		for k, v := range M[i] {
+			switch x := v.(type) {
+			case apl.Bool:
+				if x {
+					A[i][k] = 1
+				}
+			case apl.Int:
+				A[i][k] = float64(x)
+			case numbers.Float:
+				A[i][k] = float64(x)
+			default:
+				return nil, fmt.Errorf("matrix must be real: %T", v)
+			}
+		}
+	}
+	return A, nil
+}
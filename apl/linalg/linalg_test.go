@@ -0,0 +1,127 @@
+package linalg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/big"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/operators"
+	"github.com/ktye/iv/apl/primitives"
+)
+
+const (
+	float int = 1 << iota // only for floating point towers
+	small                 // normal tower only
+)
+
+var testCases = []struct {
+	in, exp string
+	flag    int
+}{
+	{"linalg→det 2 2⍴3 8 4 6", "¯14", 0},
+	{"linalg→det 2 2⍴1 2 3 4", "¯2", 0},
+	{"linalg→det 3 3⍴6 1 1 4 ¯2 5 2 8 7", "¯306", 0},
+	{"linalg→det 3 3⍴2 0 0 0 3 0 0 0 4", "24", 0},
+	{"linalg→det 3 3⍴0 1 0 1 0 0 0 0 1", "¯1", 0},
+	{"linalg→det 2 2⍴1 2 2 4", "0", 0},
+	{"linalg→det 3 3⍴1 2 3 2 4 6 1 0 1", "0", 0},
+	{"linalg→det 2 2⍴0.5 1 1.5 3.5", "0.25", small},
+	{"1E¯12>|linalg→det 3 3⍴⍳9", "1", 0},
+	{"1E¯12>|linalg→det 3 3⍴0.1×⍳9", "1", 0},
+	{"linalg→det 2 2⍴1 0 0 1E¯15", "1E¯15", small},
+	{"linalg→det 3 3⍴1E10 0 0 0 1 0 0 0 1E¯5", "100000", small},
+	{"linalg→det 5", "5", 0},
+	{"linalg→det 2 3⍴⍳6", "fail: det: matrix is not square", 0},
+	{"linalg→rank 3 3⍴1 2 3 2 4 6 1 0 1", "2", 0},
+	{"linalg→rank 3 3⍴6 1 1 4 ¯2 5 2 8 7", "3", 0},
+	{"linalg→rank 2 3⍴1 2 3 2 4 6", "1", 0},
+	{"linalg→rank 3 2⍴0", "0", 0},
+	{"linalg→rank 3 3⍴⍳9", "2", 0},
+	{"linalg→rank 4 4⍴0.1×⍳16", "2", 0},
+	{"linalg→rank 4 2⍴1 0 1 1 1 2 1 3", "2", 0},
+	{"linalg→rank 2 2⍴1 0 0 1E¯15", "2", 0},
+	{"linalg→rank 3 3⍴1E10 0 0 0 1 0 0 0 1E¯5", "3", 0},
+	{"linalg→rank 3 3⍴1E10 1 0 2E10 2 0 0 0 1E¯5", "2", 0},
+	{"linalg→rank 2 2 2⍴1", "fail: rank: argument must be a matrix", 0},
+	{"linalg→eig 2 2⍴2 1 1 2", "1 3", small},
+	{"linalg→eig 2 2⍴4 0 0 ¯1", "¯1 4", small},
+	{"linalg→eig 3 3⍴2 ¯1 0 ¯1 2 ¯1 0 ¯1 2", "0.585786 2 3.41421", small},
+	{"⌊0.5+1E9×(linalg→eig 3 3⍴2 ¯1 0 ¯1 2 ¯1 0 ¯1 2)-2+(2*÷2)×¯1 0 1", "0 0 0", small},
+	{"linalg→eig 3 3⍴2 0 0 0 3 4 0 4 9", "1 2 11", small},
+	{"linalg→eigv 2 2⍴2 1 1 2", "0.707107 0.707107\n¯0.707107 0.707107", small},
+	{"linalg→eigv 2 2⍴4 0 0 ¯1", "0 1\n1 0", small},
+	{"A←3 3⍴2 ¯1 0 ¯1 2 ¯1 0 ¯1 2⋄V←linalg→eigv A⋄⌊0.5+1E6×(A+.×V)-V×(3⍴1)∘.×linalg→eig A", "0 0 0\n0 0 0\n0 0 0", small},
+	{"linalg→eig 2 2⍴1 2 3 4", "fail: eig: matrix is not symmetric", small},
+	{"linalg→eig 2 2⍴1 2,(2+1E¯15),1", "¯1 3", small},
+	{"⎕CT←1E¯9 ⋄ linalg→eig 2 2⍴1 2 2.0000000001 1", "¯1 3", small},
+	{"linalg→eig 2 3⍴⍳6", "fail: eig: matrix is not square", small},
+	{"(2 2⍴1 1 1 0) linalg→pow 2", "2 1\n1 1", 0},
+	{"A←2 2⍴1 1 1 0 ⋄ (A linalg→pow 2)≡A+.×A", "1", 0},
+	{"(2 2⍴1 1 1 0) linalg→pow 10", "89 55\n55 34", 0},
+	{"(2 2⍴1 2 3 4) linalg→pow 1", "1 2\n3 4", 0},
+	{"(2 2⍴1 2 3 4) linalg→pow 0", "1 0\n0 1", 0},
+	{"(2 2⍴2 0 0 4) linalg→pow ¯2", "0.25 0\n0 0.0625", small},
+	{"A←2 2⍴4 7 2 6 ⋄ ⌊0.5+(A linalg→pow ¯3)+.×A linalg→pow 3", "1 0\n0 1", small},
+	{"A←4 4⍴0 1 0 0 0 0 1 0 0 0 0 0 1 0 0 0 ⋄ 0<((A∨4 4⍴1 0 0 0 0) linalg→pow 3)", "1 1 1 0\n0 1 1 0\n0 0 1 0\n1 1 1 1", 0},
+	{"(2 3⍴⍳6) linalg→pow 2", "fail: pow: matrix is not square", 0},
+	{"(2 2⍴⍳4) linalg→pow 0.5", "fail: pow: exponent must be an integer", 0},
+}
+
+func TestNormal(t *testing.T) {
+	testLinalg(t, nil, 0)
+}
+
+func TestBig(t *testing.T) {
+	testLinalg(t, big.SetBigTower, small|float)
+}
+
+func TestPrecise(t *testing.T) {
+	testLinalg(t, func(a *apl.Apl) { big.SetPreciseTower(a, 256) }, small)
+}
+
+func testLinalg(t *testing.T, tower func(*apl.Apl), skip int) {
+	for _, tc := range testCases {
+		if tc.flag&skip != 0 {
+			continue
+		}
+		var buf strings.Builder
+		a := apl.New(&buf)
+		numbers.Register(a)
+		if tower != nil {
+			tower(a)
+		}
+		primitives.Register(a)
+		operators.Register(a)
+		Register(a, "")
+
+		err := a.ParseAndEval(tc.in)
+		if strings.HasPrefix(tc.exp, "fail:") {
+			if err == nil || strings.HasPrefix(err.Error(), strings.TrimSpace(tc.exp[5:])) == false {
+				t.Fatalf("%s: expected %s, got %v", tc.in, tc.exp, err)
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("%s: %s", tc.in, err)
+		}
+		if got := buf.String(); testCompare(got, tc.exp) == false {
+			t.Fatalf("%s: expected %s, got %s", tc.in, tc.exp, got)
+		}
+	}
+}
+
+// testCompare compares the output line by line, ignoring alignment.
+func testCompare(got, exp string) bool {
+	gotlines := strings.Split(strings.TrimSpace(got), "\n")
+	explines := strings.Split(exp, "\n")
+	if len(gotlines) != len(explines) {
+		return false
+	}
+	for i, g := range gotlines {
+		if strings.Join(strings.Fields(g), " ") != strings.Join(strings.Fields(explines[i]), " ") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package linalg
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/operators"
+)
+
+// pow raises a square matrix L to the integer power R.
+// It multiplies with the inner product +.× by repeated squaring.
+// A power of 0 returns the identity matrix, a negative power uses the inverse (⌹).
+func pow(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("pow: left argument must be a matrix")
+	}
+	A, ok := L.(apl.Array)
+	if ok == false {
+		return nil, fmt.Errorf("pow: left argument must be a matrix: %T", L)
+	}
+	shape := A.Shape()
+	if len(shape) != 2 {
+		return nil, fmt.Errorf("pow: argument must be a matrix: rank %d", len(shape))
+	} else if shape[0] != shape[1] {
+		return nil, fmt.Errorf("pow: matrix is not square: %d %d", shape[0], shape[1])
+	}
+	num, ok := R.(apl.Number)
+	if ok == false {
+		return nil, fmt.Errorf("pow: exponent must be an integer: %T", R)
+	}
+	n, ok := num.ToIndex()
+	if ok == false {
+		return nil, fmt.Errorf("pow: exponent must be an integer")
+	}
+
+	if n == 0 {
+		return identity(shape[0]), nil
+	} else if n < 0 {
+		inv, err := apl.Primitive("⌹").Call(a, nil, L)
+		if err != nil {
+			return nil, fmt.Errorf("pow: %s", err)
+		}
+		L, n = inv, -n
+	}
+
+	mmul := operators.Scalarproduct(a, apl.Primitive("+"), apl.Primitive("×"))
+	var P apl.Value
+	X := L
+	for {
+		if n%2 == 1 {
+			if P == nil {
+				P = X
+			} else if p, err := mmul.Call(a, P, X); err != nil {
+				return nil, err
+			} else {
+				P = p
+			}
+		}
+		if n /= 2; n == 0 {
+			break
+		}
+		x, err := mmul.Call(a, X, X)
+		if err != nil {
+			return nil, err
+		}
+		X = x
+	}
+	return P, nil
+}
+
+// identity returns the n×n identity matrix.
+func identity(n int) apl.IntArray {
+	I := apl.IntArray{Dims: []int{n, n}, Ints: make([]int, n*n)}
+	for i := 0; i < n; i++ {
+		I.Ints[i*n+i] = 1
+	}
+	return I
+}
@@ -0,0 +1,30 @@
+// Package linalg provides linear algebra functions.
+//
+// Matrices are rank 2 arrays of any numeric tower.
+// The functions complement domino (⌹) from the primitives package.
+//
+//	linalg→det R      determinant of a square matrix
+//	linalg→rank R     rank of a matrix
+//	linalg→eig R      eigenvalues of a real symmetric matrix in ascending order
+//	linalg→eigv R     eigenvectors of a real symmetric matrix as columns
+//	L linalg→pow R    matrix L to the integer power R: L+.×L+.×…
+package linalg
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the linalg package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "linalg"
+	}
+	pkg := map[string]apl.Value{
+		"det":  apl.ToFunction(det),
+		"rank": apl.ToFunction(rank),
+		"eig":  apl.ToFunction(eig),
+		"eigv": apl.ToFunction(eigv),
+		"pow":  apl.ToFunction(pow),
+	}
+	a.RegisterPackage(name, pkg)
+}
@@ -30,6 +30,14 @@ func (l List) Copy() Value {
 	return r
 }
 
+func (l List) DeepCopy(c Copies) Value {
+	r := make(List, len(l))
+	for i := range l {
+		r[i] = c.Copy(l[i])
+	}
+	return r
+}
+
 func (l List) At(i int) Value {
 	return l[i]
 }
@@ -79,7 +87,10 @@ func (l List) getset(idx []int, v Value) (Value, error) {
 				return l[k], nil
 			}
 		}
-		if lst, ok := l[k].(List); ok == false {
+		if _, ok := l[k].(Frozen); ok && v != nil {
+			return nil, fmt.Errorf("list element is frozen")
+		}
+		if lst, ok := Unfreeze(l[k]).(List); ok == false {
 			return nil, fmt.Errorf("index is too deep")
 		} else {
 			l = lst
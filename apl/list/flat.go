@@ -0,0 +1,54 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// flat removes L levels of nesting from the list R.
+// Called monadically, only one level is removed:
+//
+//	list→flat (1;(2;(3;4;););)       (1;2;(3;4;);)
+//	2 list→flat (1;(2;(3;4;););)     (1;2;3;4;)
+//
+// Depth 0 returns R unchanged.
+// Only a depth that is at least the nesting of R flattens it completely, like enlist (∊).
+func flat(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	depth := 1
+	if L != nil {
+		n, ok := L.(apl.Number)
+		if ok == false {
+			return nil, fmt.Errorf("flat: L must be a depth: %T", L)
+		}
+		i, ok := n.ToIndex()
+		if ok == false || i < 0 {
+			return nil, fmt.Errorf("flat: L must be a non-negative integer: %s", L.String(a.Format))
+		}
+		depth = i
+	}
+	l, ok := R.(apl.List)
+	if ok == false {
+		return R.Copy(), nil
+	}
+	return flatten(l, depth), nil
+}
+
+// flatten splices list elements of l into the result, recursively up to depth levels.
+func flatten(l apl.List, depth int) apl.List {
+	if depth == 0 {
+		return l.Copy().(apl.List)
+	}
+	var res apl.List
+	for _, e := range l {
+		if v, ok := e.(apl.List); ok {
+			res = append(res, flatten(v, depth-1)...)
+		} else {
+			res = append(res, e.Copy())
+		}
+	}
+	if res == nil {
+		res = apl.List{}
+	}
+	return res
+}
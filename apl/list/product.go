@@ -0,0 +1,61 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// product returns the cartesian product of the vectors in the list R.
+// The result has the shape of the vector lengths and each element is a tuple
+// with one element of each vector: (1 2;3 4 5;) has the shape 2 3.
+// Tuples are vectors, or lists if one of their elements is an array.
+// A simple vector is a single factor, the result is a vector of 1-element tuples.
+// If R is empty or any of the vectors is empty, the result is empty.
+func product(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("product cannot be called dyadically")
+	}
+	l, ok := R.(apl.List)
+	if ok == false {
+		l = apl.List{R}
+	}
+	if len(l) == 0 {
+		return apl.EmptyArray{}, nil
+	}
+	vectors := make([]apl.Array, len(l))
+	shape := make([]int, len(l))
+	for i := range l {
+		v, err := vector(l[i])
+		if err != nil {
+			return nil, fmt.Errorf("product: %s", err)
+		}
+		if v.Size() == 0 {
+			return apl.EmptyArray{}, nil
+		}
+		vectors[i] = v
+		shape[i] = v.Size()
+	}
+
+	res := apl.MixedArray{Dims: shape, Values: make([]apl.Value, apl.Prod(shape))}
+	idx := make([]int, len(shape))
+	for k := range res.Values {
+		t := make([]apl.Value, len(idx))
+		for i, n := range idx {
+			t[i] = vectors[i].At(n).Copy()
+		}
+		res.Values[k] = tuple(a, t)
+		apl.IncArrayIndex(idx, shape)
+	}
+	return res, nil
+}
+
+// tuple returns the values as a vector, or as a list if one of them is an array.
+func tuple(a *apl.Apl, v []apl.Value) apl.Value {
+	for i := range v {
+		if _, ok := v[i].(apl.Array); ok {
+			return apl.List(v)
+		}
+	}
+	return a.UnifyArray(apl.MixedArray{Dims: []int{len(v)}, Values: v})
+}
@@ -0,0 +1,25 @@
+// Package list provides functions for nested data.
+//
+// Nested values are represented by apl.List.
+//
+//	L list→zip R       pair elements of two vectors
+//	L list→flat R      flatten L levels of nesting (default 1)
+//	list→product R     cartesian product of the vectors in R
+package list
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the list package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "list"
+	}
+	pkg := map[string]apl.Value{
+		"zip":     apl.ToFunction(zip),
+		"flat":    apl.ToFunction(flat),
+		"product": apl.ToFunction(product),
+	}
+	a.RegisterPackage(name, pkg)
+}
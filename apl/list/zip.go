@@ -0,0 +1,55 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// zip pairs corresponding elements of L and R.
+// The result is a list of 2-element vectors: (l1 r1;l2 r2;...;).
+// If the arguments have different lengths, the longer one is truncated.
+// A pair is a list, if any of its elements is an array.
+func zip(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("zip must be called dyadically")
+	}
+	l, err := vector(L)
+	if err != nil {
+		return nil, fmt.Errorf("zip: left argument: %s", err)
+	}
+	r, err := vector(R)
+	if err != nil {
+		return nil, fmt.Errorf("zip: right argument: %s", err)
+	}
+	n := l.Size()
+	if m := r.Size(); m < n {
+		n = m
+	}
+	res := make(apl.List, n)
+	for i := range res {
+		res[i] = pair(a, l.At(i).Copy(), r.At(i).Copy())
+	}
+	return res, nil
+}
+
+// pair returns a 2-element vector, or a list if one of the values is an array.
+func pair(a *apl.Apl, x, y apl.Value) apl.Value {
+	return tuple(a, []apl.Value{x, y})
+}
+
+// vector returns v as a rank 1 array.
+// A scalar is returned as a 1-element vector.
+func vector(v apl.Value) (apl.Array, error) {
+	ar, ok := v.(apl.Array)
+	if ok == false {
+		return apl.List{v}, nil
+	}
+	if _, ok := ar.(apl.EmptyArray); ok {
+		return ar, nil
+	}
+	if s := ar.Shape(); len(s) != 1 {
+		return nil, fmt.Errorf("must be a vector: rank is %d", len(s))
+	}
+	return ar, nil
+}
@@ -0,0 +1,61 @@
+package apl
+
+import "sync"
+
+// Memo is the result cache of a memoized function, see the ⍥ operator.
+// It is safe for concurrent use.
+type Memo struct {
+	sync.Mutex
+	m map[string]Value
+}
+
+// Get returns the cached value for the key.
+func (m *Memo) Get(key string) (Value, bool) {
+	m.Lock()
+	defer m.Unlock()
+	v, ok := m.m[key]
+	return v, ok
+}
+
+// Set stores a value in the cache.
+// A full cache is cleared before, see memoSize.
+func (m *Memo) Set(key string, v Value) {
+	m.Lock()
+	defer m.Unlock()
+	if len(m.m) >= memoSize {
+		m.m = make(map[string]Value)
+	}
+	m.m[key] = v
+}
+
+// memoSize is the maximum number of results of a memoized function.
+const memoSize = 1 << 16
+
+// memoFuncs is the maximum number of memoized functions.
+// Each redefinition of a memoized lambda function creates a new cache,
+// while the old one cannot be used anymore.
+const memoFuncs = 1 << 10
+
+// memoTable holds the caches of all memoized functions of an interpreter.
+type memoTable struct {
+	sync.Mutex
+	m map[Function]*Memo
+}
+
+// MemoCache returns the cache for the function f.
+// Caches belong to the interpreter and are released with it.
+// Forks share the caches of their parent.
+// If there are too many caches, all of them are dropped, see memoFuncs.
+func (a *Apl) MemoCache(f Function) *Memo {
+	a.memo.Lock()
+	defer a.memo.Unlock()
+	c, ok := a.memo.m[f]
+	if ok == false {
+		if len(a.memo.m) >= memoFuncs {
+			a.memo.m = make(map[Function]*Memo)
+		}
+		c = &Memo{m: make(map[string]Value)}
+		a.memo.m[f] = c
+	}
+	return c
+}
@@ -0,0 +1,28 @@
+package apl
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestMemoSize(t *testing.T) {
+	var m Memo
+	m.m = make(map[string]Value)
+	for i := 0; i <= memoSize; i++ {
+		m.Set(strconv.Itoa(i), Int(i))
+	}
+	if n := len(m.m); n != 1 {
+		t.Fatalf("expected a cleared cache with 1 entry, got %d", n)
+	}
+	if v, ok := m.Get(strconv.Itoa(memoSize)); ok == false || v != Int(memoSize) {
+		t.Fatalf("last value is not cached: %v", v)
+	}
+
+	a := New(nil)
+	for i := 0; i <= memoFuncs; i++ {
+		a.MemoCache(&lambda{})
+	}
+	if n := len(a.memo.m); n != 1 {
+		t.Fatalf("expected 1 memo cache, got %d", n)
+	}
+}
@@ -0,0 +1,137 @@
+package apl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Namespace is a value that holds variables and functions like a package.
+// It is stored in a value variable (uppercase) and members are referenced
+// with the arrow syntax:
+//	N←a→n 0          ⍝ create an empty namespace
+//	N→X←1 2 3        ⍝ assign a value
+//	N→f←{⍵+N→X}      ⍝ assign a function
+//	N→f 1            ⍝ call it: 2 3 4
+// A Namespace is also an Object, keys are the member names:
+//	#N               ⍝ `X`f
+//	N[`X]            ⍝ 1 2 3
+// Namespaces are references: a copy refers to the same members.
+// Use DeepCopy for an independent namespace.
+// Members are guarded by a mutex, as forked interpreters share the namespace.
+type Namespace struct {
+	sync.RWMutex
+	env *env
+}
+
+// NewNamespace returns an empty namespace.
+func NewNamespace() *Namespace {
+	return &Namespace{env: &env{vars: make(map[string]Value)}}
+}
+
+func (n *Namespace) Copy() Value {
+	return n
+}
+
+// DeepCopy returns a new namespace with copies of all members.
+// A namespace that contains itself, refers to the new namespace.
+func (n *Namespace) DeepCopy(c Copies) Value {
+	if r, ok := c[n]; ok {
+		return r
+	}
+	r := NewNamespace()
+	c[n] = r
+	for k, v := range n.vars() {
+		r.env.vars[k] = c.Copy(v)
+	}
+	return r
+}
+
+// vars returns a snapshot of the members.
+func (n *Namespace) vars() map[string]Value {
+	n.RLock()
+	defer n.RUnlock()
+	m := make(map[string]Value, len(n.env.vars))
+	for k, v := range n.env.vars {
+		m[k] = v
+	}
+	return m
+}
+
+func (n *Namespace) get(name string) Value {
+	n.RLock()
+	defer n.RUnlock()
+	return n.env.vars[name]
+}
+
+func (n *Namespace) set(name string, v Value) {
+	n.Lock()
+	defer n.Unlock()
+	n.env.vars[name] = v
+}
+
+// String prints the members with their values.
+// A namespace that contains itself, is printed as (cycle) at the inner level.
+func (n *Namespace) String(f Format) string {
+	if f.namespaces[n] {
+		return "(cycle)"
+	}
+	m := make(map[*Namespace]bool, len(f.namespaces)+1)
+	for k := range f.namespaces {
+		m[k] = true
+	}
+	m[n] = true
+	f.namespaces = m
+
+	var buf strings.Builder
+	tw := tabwriter.NewWriter(&buf, 1, 0, 1, ' ', 0)
+	for _, k := range n.Keys() {
+		fmt.Fprintf(tw, "%s:\t%s\n", k.String(f), n.At(k).String(f))
+	}
+	tw.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Keys returns the member names in sorted order.
+func (n *Namespace) Keys() []Value {
+	n.RLock()
+	names := make([]string, 0, len(n.env.vars))
+	for k := range n.env.vars {
+		names = append(names, k)
+	}
+	n.RUnlock()
+	sort.Strings(names)
+	keys := make([]Value, len(names))
+	for i, k := range names {
+		keys[i] = String(k)
+	}
+	return keys
+}
+
+func (n *Namespace) At(key Value) Value {
+	name, ok := key.(String)
+	if ok == false {
+		return nil
+	}
+	return n.get(string(name))
+}
+
+// Set assigns a member.
+// The key must be a variable name, that matches the kind of the value.
+func (n *Namespace) Set(key Value, v Value) error {
+	name, ok := key.(String)
+	if ok == false {
+		return fmt.Errorf("namespace: key must be a string: %T", key)
+	}
+	ok, isfunc := isVarname(string(name))
+	if ok == false || strings.ContainsRune(string(name), '→') {
+		return fmt.Errorf("namespace: member name is not allowed: %s", name)
+	}
+	if err := checkKind(string(name), v, isfunc); err != nil {
+		return err
+	}
+	n.set(string(name), v)
+	return nil
+}
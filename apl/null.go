@@ -0,0 +1,11 @@
+package apl
+
+// Null is a value that represents a missing value.
+// It is different from the empty array.
+// Null is returned by ⎕NULL and is formatted as null.
+// It compares equal only to itself and is not a number,
+// arithmetic fails with a domain error.
+type Null struct{}
+
+func (n Null) String(f Format) string { return "null" }
+func (n Null) Copy() Value            { return n }
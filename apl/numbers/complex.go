@@ -17,8 +17,8 @@ type Complex complex128
 // If it contains an "a", two format strings are assumed and magnitude
 // and degree are passed to fmt.
 // Otherwise real and imag parts are passed.
-// By default - is replaced with ¯, expept if the format string
-// starts with -.
+// By default - is replaced with ¯ and infinite parts are printed as ∞,
+// expept if the format string starts with -.
 // Examples:
 //	"%.3f", "%ga%.0f", "-%v", "%.5fJ%.5f"
 func (c Complex) String(f apl.Format) string {
@@ -58,7 +58,11 @@ func (c Complex) String(f apl.Format) string {
 		s = fmt.Sprintf(format, a, b)
 	}
 	if minus == false {
+		s = InfString(s)
 		s = strings.Replace(s, "-", "¯", -1)
+		if f.Dec != "" {
+			s = strings.Replace(s, ".", f.Dec, -1)
+		}
 	}
 	return s
 }
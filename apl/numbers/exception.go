@@ -3,42 +3,30 @@ package numbers
 import (
 	"math"
 	"math/cmplx"
-
-	"github.com/ktye/iv/apl"
+	"strings"
 )
 
-const (
-	NaN    exception = "NaN"
-	Inf    exception = "∞"
-	NegInf exception = "¯∞"
+// Inf, NegInf and NaN are the results of arithmetic exceptions.
+// They are Floats with IEEE semantics.
+var (
+	NaN    = Float(math.NaN())
+	Inf    = Float(math.Inf(1))
+	NegInf = Float(math.Inf(-1))
 )
 
-type exception string
-
-func (e exception) String(f apl.Format) string {
-	return string(e)
-}
-func (e exception) Copy() apl.Value { return e }
-
-func isException(n apl.Number) (exception, bool) {
-	if f, ok := n.(Float); ok {
-		if math.IsNaN(float64(f)) {
-			return NaN, true
-		}
-		if math.IsInf(float64(f), 1) {
-			return Inf, true
-		}
-		if math.IsInf(float64(f), -1) {
-			return NegInf, true
-		}
+// isException returns the real infinity or NaN, if a complex number is not finite.
+func isException(c Complex) (Float, bool) {
+	if cmplx.IsNaN(complex128(c)) {
+		return NaN, true
 	}
-	if c, ok := n.(Complex); ok {
-		if cmplx.IsNaN(complex128(c)) {
-			return NaN, true
-		}
-		if cmplx.IsInf(complex128(c)) {
-			return Inf, true
-		}
+	if cmplx.IsInf(complex128(c)) {
+		return Inf, true
 	}
-	return "", false
+	return 0, false
+}
+
+// InfString replaces infinities formatted by fmt with ∞.
+// The sign of a negative infinity is kept as -.
+func InfString(s string) string {
+	return strings.NewReplacer("+Inf", "∞", "-Inf", "-∞").Replace(s)
 }
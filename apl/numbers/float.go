@@ -15,8 +15,20 @@ type Float float64
 // String formats a Float as a string.
 // The format string is passed to fmt and - is replaced by ¯,
 // except if the first rune is -.
+// In the same case, infinities are printed as ∞ and ¯∞.
+// In the same case, the period is replaced by the decimal separator f.Dec, if set
+// and the digits are grouped by f.Group.
 func (n Float) String(f apl.Format) string {
 	format, minus := getformat(f, n)
+	if minus == false {
+		if math.IsInf(float64(n), 1) {
+			return "∞"
+		} else if math.IsInf(float64(n), -1) {
+			return "¯∞"
+		} else if math.IsNaN(float64(n)) {
+			return "NaN"
+		}
+	}
 	if format == "" {
 		switch prec := f.PP; {
 		case prec == 0:
@@ -32,6 +44,10 @@ func (n Float) String(f apl.Format) string {
 	s := fmt.Sprintf(format, float64(n))
 	if minus == false {
 		s = strings.Replace(s, "-", "¯", -1)
+		if f.Dec != "" {
+			s = strings.Replace(s, ".", f.Dec, -1)
+		}
+		s = apl.GroupDigits(f, s)
 	}
 	return s
 }
@@ -39,8 +55,12 @@ func (f Float) Copy() apl.Value { return f }
 
 // ParseFloat parses a Float. It replaces ¯ with -, then uses ParseFloat.
 // A trailing . is stripped, so that "2." is parsed as a float.
+// The infinity literals ∞ and ¯∞ are accepted.
 func ParseFloat(s string) (apl.Number, bool) {
 	s = strings.Replace(s, "¯", "-", -1)
+	if s == "∞" || s == "-∞" {
+		s = strings.Replace(s, "∞", "Inf", 1)
+	}
 	if n, err := strconv.ParseFloat(strings.TrimSuffix(s, "."), 64); err == nil {
 		return Float(n), true
 	}
@@ -91,18 +111,10 @@ func (f Float) Mul2(R apl.Value) (apl.Value, bool) {
 }
 
 func (f Float) Div() (apl.Value, bool) {
-	n := 1.0 / float64(f)
-	if e, ok := isException(Float(n)); ok {
-		return e, true
-	}
-	return Float(n), true
+	return Float(1.0 / float64(f)), true
 }
 func (f Float) Div2(b apl.Value) (apl.Value, bool) {
-	n := Float(float64(f) / float64(b.(Float)))
-	if e, ok := isException(n); ok {
-		return e, true
-	}
-	return Float(n), true
+	return Float(float64(f) / float64(b.(Float))), true
 }
 
 func (f Float) Pow() (apl.Value, bool) {
@@ -113,22 +125,12 @@ func (f Float) Pow2(R apl.Value) (apl.Value, bool) {
 }
 
 func (f Float) Log() (apl.Value, bool) {
-	l := math.Log(float64(f))
-	if e, ok := isException(Float(l)); ok {
-		return e, true
-	}
-	return Float(l), true
+	return Float(math.Log(float64(f))), true
 }
 func (f Float) Log2(R apl.Value) (apl.Value, bool) {
 	l := math.Log(float64(f))
 	r := math.Log(float64(R.(Float)))
-	if e, ok := isException(Float(l)); ok {
-		return e, true
-	}
-	if e, ok := isException(Float(r)); ok {
-		return e, true
-	}
-	return Float(r) / Float(l), true
+	return Float(r / l), true
 }
 
 func (f Float) Abs() (apl.Value, bool) {
@@ -146,11 +148,7 @@ func (f Float) Ceil() (apl.Value, bool) {
 }
 
 func (f Float) Gamma() (apl.Value, bool) {
-	y := Float(math.Gamma(float64(f) + 1))
-	if e, ok := isException(y); ok {
-		return e, true
-	}
-	return y, true
+	return Float(math.Gamma(float64(f) + 1)), true
 }
 
 func beta(a, b float64) float64 {
@@ -166,11 +164,7 @@ func (L Float) Gamma2(R apl.Value) (apl.Value, bool) {
 	// L!R = (R over L) = 1/((R-L)*beta(R-L, L+1))
 	r := float64(R.(Float))
 	l := float64(L)
-	f := Float(1.0 / ((r - l) * beta(r-l, l+1)))
-	if e, ok := isException(f); ok {
-		return e, true
-	}
-	return f, true
+	return Float(1.0 / ((r - l) * beta(r-l, l+1))), true
 }
 
 func (L Float) PiTimes() (apl.Value, bool) {
@@ -226,15 +220,13 @@ func (L Float) Trig(R apl.Value) (apl.Value, bool) {
 	case -11:
 		return nil, false
 	case 11:
-		y = x // imag part
+		y = 0 // imag part
 	case -12:
 		return nil, false
 	case 12: // phase
-		y = 1
-		if x == 0 {
-			y = 0
-		} else if x < 0 {
-			y = -1
+		y = 0
+		if x < 0 {
+			y = math.Pi
 		}
 	default:
 		return nil, false
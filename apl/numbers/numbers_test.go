@@ -1,6 +1,7 @@
 package numbers
 
 import (
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -23,6 +24,8 @@ func TestParse(t *testing.T) {
 		{"3.12E¯2", Float(0.0312)},
 		{".5", Float(0.5)},
 		{"¯.3", Float(-0.3)},
+		{"∞", Float(math.Inf(1))},
+		{"¯∞", Float(math.Inf(-1))},
 		{"2014.04.02", Time(time.Date(2014, 4, 2, 0, 0, 0, 0, time.UTC))},
 		{"2014.04.02T09.37.22", Time(time.Date(2014, 4, 2, 9, 37, 22, 0, time.UTC))},
 		{"10s", Time(y0.Add(10 * time.Second))},
@@ -54,6 +54,7 @@ func newTower() apl.Tower {
 			return n
 		},
 		Uniform: makeUniform,
+		Prec:    53,
 	}
 	return t
 }
@@ -76,15 +77,13 @@ func makeUniform(v []apl.Value) (apl.Value, bool) {
 	return nil, false
 }
 
-func makeBoolArray(v []apl.Value) apl.BoolArray {
+// makeBoolArray returns a BoolArray or a BitArray for large sizes.
+func makeBoolArray(v []apl.Value) apl.Uniform {
 	f := make([]bool, len(v))
 	for i, e := range v {
 		f[i] = bool(e.(apl.Bool))
 	}
-	return apl.BoolArray{
-		Dims:  []int{len(v)},
-		Bools: f,
-	}
+	return apl.MakeBoolArray([]int{len(v)}, f)
 }
 func makeIndexArray(v []apl.Value) apl.IntArray {
 	f := make([]int, len(v))
@@ -2,6 +2,7 @@ package numbers
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,13 +30,26 @@ func ParseTime(s string) (apl.Number, bool) {
 			return Time(t), true
 		}
 	}
-	if d, err := time.ParseDuration(s); err == nil {
+	if d, ok := ParseDuration(s); ok {
 		return Time(y0.Add(d)), true
 	}
 
 	return nil, false
 }
 
+// ParseDuration parses a duration such as 1h30m, ¯2s or 500ms.
+// It accepts go duration strings and APL notation with a high minus.
+// Components may be separated by blanks, as FormatDuration writes them: 1h 30m.
+func ParseDuration(s string) (time.Duration, bool) {
+	s = strings.Replace(s, "¯", "-", -1)
+	s = strings.Replace(s, " ", "", -1)
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
 // We cannot separate with colons.
 // Mon Jan 2 15:04:05 -0700 MST 2006
 var layouts = []string{
@@ -65,6 +79,10 @@ func (t Time) String(f apl.Format) string {
 
 	if format == "" {
 		format = "2006.01.02T15.04.05.000"
+		// Times with a location other than UTC or Local show the zone abbreviation.
+		if loc := time.Time(t).Location(); loc != time.UTC && loc != time.Local {
+			format += " MST"
+		}
 	}
 
 	return time.Time(t).Format(format)
@@ -75,6 +93,11 @@ func (t Time) ToIndex() (int, bool) {
 	return 0, false
 }
 
+// Equals compares the time instants, ignoring the location.
+func (t Time) Equals(R apl.Value) (apl.Bool, bool) {
+	return apl.Bool(time.Time(t).Equal(time.Time(R.(Time)))), true
+}
+
 func (t Time) Less(R apl.Value) (apl.Bool, bool) {
 	return apl.Bool(time.Time(t).Before(time.Time(R.(Time)))), true
 }
@@ -123,6 +146,11 @@ func (t Time) Sub2(R apl.Value) (apl.Value, bool) {
 	return nil, false
 }
 
+// NewDuration returns a time value that represents the duration d.
+func NewDuration(d time.Duration) Time {
+	return Time(y0.Add(d))
+}
+
 // Duration returns if the time value is a duration and it's value.
 func (t Time) Duration() (time.Duration, bool) {
 	if time.Time(t).Before(y1k) {
@@ -283,9 +311,71 @@ func roundDuration(t time.Duration, s string) (Time, error) {
 	return Time(y0.Add(t.Round(d))), nil
 }
 
+// durationUnits are the components of a formatted duration.
+var durationUnits = []struct {
+	name string
+	d    time.Duration
+}{
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"us", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// FormatDuration formats a duration as space separated components, e.g. "2h 48m 46.8s".
+// Components that are zero are omitted.
+// If unit is given, the duration is truncated to the unit, which is the smallest component:
+//
+//	FormatDuration(d, "m")    2h 48m
+//
+// Without a unit, the last component is fractional seconds.
+func FormatDuration(d time.Duration, unit string) (string, error) {
+	last := len(durationUnits) - 1
+	if unit == "μs" {
+		unit = "us"
+	}
+	if unit != "" {
+		last = -1
+		for i, u := range durationUnits {
+			if u.name == unit {
+				last = i
+			}
+		}
+		if last < 0 {
+			return "", fmt.Errorf("unknown unit: %s", unit)
+		}
+		d = d.Truncate(durationUnits[last].d)
+	}
+	sign := ""
+	if d < 0 {
+		sign = "¯"
+		d = -d
+	}
+	var v []string
+	for i, u := range durationUnits[:last+1] {
+		if unit == "" && u.d == time.Second {
+			if d != 0 {
+				v = append(v, strconv.FormatFloat(d.Seconds(), 'f', -1, 64)+"s")
+			}
+			break
+		}
+		if n := d / u.d; n != 0 || (i == last && len(v) == 0) {
+			v = append(v, strconv.Itoa(int(n))+u.name)
+			d -= n * u.d
+		}
+	}
+	if len(v) == 0 {
+		return "0s", nil
+	}
+	return sign + strings.Join(v, " "), nil
+}
+
 // Not supported by elementary arithmetics on time numbers:
 // - Add non-constant intervals to time, e.g. 2016.01.01 + 1 year (go: time.AddDate)
-//	Currently we can only do:
+//	This is provided by the date package: 1 date→addyears 2016.01.01
+//	With arithmetics we can only do:
 //      	2015.01.01 + 365×24h
 //	2016.12.31T00.00.00.000
 //	       2015.01.01 + 365×24h
@@ -35,6 +35,15 @@ type Object interface {
 	Set(Value, Value) error
 }
 
+// Receiver is implemented by objects with channel fields, such as xgo values.
+// Receive reads a value from the channel stored under the key.
+// It returns false, if the key is not a channel.
+// Indexing a channel field receives: Object[`ch],
+// while At does not, as it is also used for printing.
+type Receiver interface {
+	Receive(Value) (Value, bool)
+}
+
 // Dict is a dictionary object.
 // A Dict is created with the L#R, where
 // L is a key or a vector of keys and R conforming values.
@@ -44,6 +53,12 @@ type Object interface {
 //	D←`a`b`c#1 2 3   ⍝ 3 Keys
 //	D[`a]            ⍝ returns value 1
 //	D[`a`c]          ⍝ returns a dict with 2 keys
+// If all keys are symbols and all values are vectors of the same length,
+// it is displayed as a table with the keys as column headers, otherwise as key: value lines.
+// Such a dict can be used column-wise:
+//	D[`a`c]          ⍝ select columns
+//	(D[`a]>2)/D      ⍝ filter rows
+//	f¨D              ⍝ apply f to each column
 type Dict struct {
 	K []Value
 	M map[Value]Value
@@ -80,6 +95,12 @@ func (d *Dict) String(f Format) string {
 	} else if f.PP == -3 {
 		return d.matString(f)
 	}
+	if rows, ok := d.columns(); ok {
+		var buf strings.Builder
+		if err := (Table{Dict: d, Rows: rows}).WriteFormatted(f, nil, &buf); err == nil {
+			return strings.TrimSuffix(buf.String(), "\n")
+		}
+	}
 	var buf strings.Builder
 	tw := tabwriter.NewWriter(&buf, 1, 0, 1, ' ', 0)
 	for _, k := range d.K {
@@ -93,6 +114,32 @@ func (d *Dict) String(f Format) string {
 	return s
 }
 
+// columns returns if all keys are symbols and all values are vectors of the same length.
+// Such a dict is displayed as a table with the keys as column headers.
+// Single element vectors are not, e.g. a single row indexed from a table.
+// Dicts with other keys, such as the numeric keys of group by, are not tables.
+func (d *Dict) columns() (int, bool) {
+	if len(d.K) == 0 {
+		return 0, false
+	}
+	n := -1
+	for _, k := range d.K {
+		if _, ok := k.(String); ok == false {
+			return 0, false
+		}
+		ar, ok := d.M[k].(Array)
+		if ok == false {
+			return 0, false
+		}
+		shape := ar.Shape()
+		if len(shape) != 1 || (n >= 0 && shape[0] != n) {
+			return 0, false
+		}
+		n = shape[0]
+	}
+	return n, n > 1
+}
+
 func (d *Dict) Copy() Value {
 	r := Dict{}
 	if d.K != nil {
@@ -110,6 +157,24 @@ func (d *Dict) Copy() Value {
 	return &r
 }
 
+// DeepCopy copies the dictionary including nested namespaces or xgo values.
+func (d *Dict) DeepCopy(c Copies) Value {
+	r := Dict{}
+	if d.K != nil {
+		r.K = make([]Value, len(d.K))
+		for i := range d.K {
+			r.K[i] = d.K[i].Copy()
+		}
+	}
+	if d.M != nil {
+		r.M = make(map[Value]Value)
+		for k, v := range d.M {
+			r.M[k.Copy()] = c.Copy(v)
+		}
+	}
+	return &r
+}
+
 func (d *Dict) jsonString(f Format) string {
 	var b strings.Builder
 	b.WriteRune('{')
@@ -33,9 +33,10 @@ type Operator interface {
 type derived struct {
 	op string
 	// operands of the derived expression
-	lo  expr                                       // left operand
-	ro  expr                                       // right operand
-	sel func(*Apl, Value, Value) (IntArray, error) // selection function for reduce and scan
+	lo   expr                                       // left operand
+	ro   expr                                       // right operand
+	sel  func(*Apl, Value, Value) (IntArray, error) // selection function for reduce and scan
+	axis expr                                       // axis of the modifier in a modified assignment: A+←[2]V
 }
 
 func (d *derived) Eval(a *Apl) (Value, error) {
@@ -58,6 +59,14 @@ func (d *derived) String(f Format) string {
 }
 func (d *derived) Copy() Value { return d }
 
+// Operands returns the operator symbol and the operands of a derived function.
+// Operands that are not values, e.g. unevaluated expressions, are returned as nil.
+func (d *derived) Operands() (string, Value, Value) {
+	lo, _ := d.lo.(Value)
+	ro, _ := d.ro.(Value)
+	return d.op, lo, ro
+}
+
 // Call tries to call a derived function.
 // l and r are the left and right values to the derived function.
 // The left and right operands are stored at d.lo and d.ro.
@@ -85,6 +94,9 @@ func (d *derived) Call(a *Apl, l, r Value) (Value, error) {
 		// Modified assignment contains the expr with the identifier in the left argument,
 		// otherwise it is the LO.
 		if l == nil {
+			if d.axis != nil {
+				return nil, fmt.Errorf("assignment with axis requires a modifying function")
+			}
 			lo, err = evalAssign(a, d.lo, nil)
 			if err != nil {
 				return nil, err
@@ -102,6 +114,13 @@ func (d *derived) Call(a *Apl, l, r Value) (Value, error) {
 					return nil, fmt.Errorf("modifier is not a function: %T", d.lo)
 				}
 			}
+			if d.axis != nil {
+				if f == nil {
+					return nil, fmt.Errorf("assignment with axis requires a modifying function")
+				}
+				// A f←[X] R is the same as A f[X]← R.
+				f = &derived{op: "⍂", lo: d.lo, ro: d.axis}
+			}
 			lo, err = evalAssign(a, as, f)
 			if err != nil {
 				return nil, err
@@ -113,6 +132,7 @@ func (d *derived) Call(a *Apl, l, r Value) (Value, error) {
 		if err != nil {
 			return nil, err
 		}
+		l, r = Unfreeze(l), Unfreeze(r)
 	}
 
 	for _, op := range ops {
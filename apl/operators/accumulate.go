@@ -0,0 +1,76 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⍫",
+		Domain:  DyadicOp(Split(Function(nil), nil)),
+		doc:     "map accumulate, scan with state",
+		derived: mapAccumulate,
+	})
+}
+
+// mapAccumulate is a scan that carries a state, similar to mapAccumL in haskell.
+// The right operand is the initial state.
+// F is called with the current state as the left argument and each item of R as the right.
+// It must return a pair (new state, output), as a 2 element vector or list.
+// The result is a list of the final state and the outputs.
+//	{(⍺⌈⍵),⍺⌈⍵}⍫0⊢3 1 4 1 5   ⍝ (5;3 3 4 4 5;) running maximum
+func mapAccumulate(a *apl.Apl, LO, RO apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		if L != nil {
+			return nil, fmt.Errorf("map accumulate cannot be called dyadically")
+		}
+		if _, ok := RO.(apl.Function); ok {
+			return nil, fmt.Errorf("map accumulate: initial state must be a value")
+		}
+		state := RO.Copy()
+		var out []apl.Value
+		for _, x := range items(a, R) {
+			v, err := f.Call(a, state, x)
+			if err != nil {
+				return nil, err
+			}
+			var o apl.Value
+			state, o, err = statePair(v)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, o)
+		}
+		return apl.List{state, listOrArray(a, out)}, nil
+	}
+	return function(derived)
+}
+
+// statePair splits the return value of the function in map accumulate.
+func statePair(v apl.Value) (apl.Value, apl.Value, error) {
+	if l, ok := v.(apl.List); ok && len(l) == 2 {
+		return l[0], l[1], nil
+	} else if ar, ok := v.(apl.Array); ok {
+		if s := ar.Shape(); len(s) == 1 && s[0] == 2 {
+			return ar.At(0).Copy(), ar.At(1).Copy(), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("map accumulate: function must return a pair (state;output)")
+}
+
+// listOrArray returns a uniform vector, if all values are scalars and a list otherwise.
+func listOrArray(a *apl.Apl, values []apl.Value) apl.Value {
+	if len(values) == 0 {
+		return apl.EmptyArray{}
+	}
+	for _, v := range values {
+		if _, ok := v.(apl.Array); ok {
+			return apl.List(values)
+		}
+	}
+	return a.UnifyArray(apl.MixedArray{Dims: []int{len(values)}, Values: values})
+}
@@ -33,6 +33,12 @@ func assign(a *apl.Apl, f, g apl.Value) apl.Function {
 			}
 			return assignVector(a, as.Identifiers, R, as.Modifier)
 		}
+		if as.Targets != nil {
+			if as.Indexes != nil {
+				return nil, fmt.Errorf("list and indexed assignment cannot exist simulaneously")
+			}
+			return R, assignNested(a, as.Targets, R)
+		}
 
 		// Special case: channel scope: ⎕←C
 		if c, ok := R.(apl.Channel); ok && as.Identifier == "⎕" {
@@ -86,6 +92,42 @@ func assignVector(a *apl.Apl, names []string, R apl.Value, mod apl.Function) (ap
 	return R, nil
 }
 
+// AssignNested destructures R into the nested targets of a list assignment.
+// R must be a vector (usually a list) with one item for each target.
+// A scalar or a single item is assigned to all targets.
+func assignNested(a *apl.Apl, targets []*apl.Assignment, R apl.Value) error {
+	var ar apl.Array
+	if v, ok := R.(apl.Array); ok {
+		ar = v
+	} else {
+		ar = apl.List{R}
+	}
+	if s := ar.Shape(); len(s) != 1 {
+		return fmt.Errorf("list assignment: rank of right argument must be 1")
+	} else if s[0] != 1 && s[0] != len(targets) {
+		return fmt.Errorf("list assignment: %d targets but %d values", len(targets), s[0])
+	}
+	for i, t := range targets {
+		k := i
+		if ar.Size() == 1 {
+			k = 0
+		}
+		v := ar.At(k)
+		var err error
+		if t.Identifiers != nil {
+			_, err = assignVector(a, t.Identifiers, v, t.Modifier)
+		} else if t.Targets != nil {
+			err = assignNested(a, t.Targets, v)
+		} else {
+			err = assignScalar(a, t.Identifier, nil, t.Modifier, v)
+		}
+		if err != nil {
+			return fmt.Errorf("list assignment: target %d: %s", i+a.Origin, err)
+		}
+	}
+	return nil
+}
+
 // AssignScalar assigns to a named scalar variable.
 // If indexes is non-nil, it must be an IndexArray for indexed assignment.
 // Mod may be a dyadic modifying function.
@@ -94,22 +136,37 @@ func assignScalar(a *apl.Apl, name string, indexes apl.Value, f apl.Function, R
 		return a.Assign(name, R.Copy())
 	}
 
+	// The modified value is stored in the environment where the variable is defined.
+	// With ⊢ as the modifier, this overwrites a variable in an outer scope.
 	w, env := a.LookupEnv(name)
 	if w == nil {
 		return fmt.Errorf("assign %s: modified/indexed: variable does not exist", name)
+	} else if _, ok := w.(apl.Frozen); ok {
+		return fmt.Errorf("assign %s: value is frozen", name)
 	}
 
+	// A reference that is shared with a frozen value is copied before it is modified.
+	w, thawed := a.Thaw(w)
+
 	v, err := assignValue(a, w, indexes, f, R)
 	if err != nil {
 		return fmt.Errorf("assign %s: %s", name, err)
 	}
 	if v != nil {
 		return a.AssignEnv(name, v.Copy(), env)
+	} else if thawed {
+		return a.AssignEnv(name, w, env)
 	}
 	return nil
 }
 
 // assignValue assigns to a given value. It may return a new value, or nil with no error.
+//
+// With a modifying function f, indexed assignment updates each indexed element
+// with the result of f called with the current and the new value: A[I]{⍺⌈⍵}←V.
+// Indexes are processed in order. If an index is repeated, f is applied cumulatively:
+//	A←0 0 0 ⋄ A[1 1 2]+←1 2 3 ⋄ A  ⍝ 3 3 0
+// Without a modifier, the last value for a repeated index is stored.
 func assignValue(a *apl.Apl, dst apl.Value, indexes apl.Value, f apl.Function, R apl.Value) (apl.Value, error) {
 	// Modified assignment without indexing.
 	if indexes == nil {
@@ -475,7 +532,10 @@ func assignObjectDepth(a *apl.Apl, obj apl.Object, idx apl.IntArray, f apl.Funct
 	v := obj.At(key)
 	if v == nil {
 		return fmt.Errorf("assign obj-depth: nil value")
+	} else if _, ok := v.(apl.Frozen); ok {
+		return fmt.Errorf("assign obj-depth: value is frozen")
 	}
+	v, _ = a.Thaw(v)
 
 	ia := apl.IntArray{Dims: []int{idx.Dims[0] - 1}, Ints: idx.Ints[1:]}
 	if _, ok := v.(apl.Table); ok {
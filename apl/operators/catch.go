@@ -0,0 +1,46 @@
+package operators
+
+import (
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⎊",
+		Domain:  DyadicOp(Split(Function(nil), Function(nil))),
+		doc:     "catch, call g if f fails",
+		derived: catch,
+	})
+}
+
+// catch calls f. If it fails, g is called with the error value
+// as the left argument and the original right argument.
+// The error value is an object with the keys message and code.
+//	{÷⍵}⎊{⍺[`message]}'a'
+//	{÷⍵}⎊{↯⍺}'a'            ⍝ signal again
+// An interrupted evaluation is not caught.
+func catch(a *apl.Apl, LO, RO apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	g := RO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		var r apl.Value
+		if R != nil {
+			r = R.Copy()
+		}
+		v, err := f.Call(a, L, R)
+		if err == nil {
+			return v, nil
+		}
+		if a.Interrupted() != nil {
+			// An interrupt is not caught.
+			return nil, err
+		}
+		e, ok := err.(apl.Error)
+		if ok == false {
+			e = apl.Error{E: err}
+		}
+		return g.Call(a, e, r)
+	}
+	return function(derived)
+}
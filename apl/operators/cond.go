@@ -0,0 +1,87 @@
+package operators
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⍰",
+		Domain:  DyadicOp(Split(Function(nil), Function(nil))),
+		doc:     "if, apply g if predicate f is true",
+		derived: condIf,
+	})
+	register(operator{
+		symbol:  "⍠",
+		Domain:  DyadicOp(Split(Function(nil), Function(nil))),
+		doc:     "else, apply g if the condition of f is false",
+		derived: condElse,
+	})
+}
+
+// errCondition is returned by p⍰f if the predicate is false.
+// It is caught by ⍠.
+var errCondition = fmt.Errorf("condition is false and there is no else branch")
+
+// unhandled converts errCondition returned by a nested conditional
+// to an ordinary error, which is not caught by an enclosing ⍠.
+// Only the predicate of the ⍰ that is the left operand of ⍠ selects the else branch.
+func unhandled(err error) error {
+	if err == errCondition {
+		return errors.New(err.Error())
+	}
+	return err
+}
+
+// condIf calls the predicate p with the arguments of the derived function.
+// The predicate is applied to the whole argument and must return a boolean scalar.
+// If it is true, f is applied.
+// Otherwise the call fails, unless it is the left operand of ⍠.
+// To select for each element, use each:
+//	(0∘<)⍰{⍵*0.5}⍠- 9       ⍝ 3
+//	(0∘<)⍰{⍵*0.5}⍠-¨¯4 9    ⍝ 4 3
+// Operators bind from the left, an else-if chain must be parenthesized:
+//	(0∘<)⍰-⍠((0∘>)⍰+⍠{100})
+func condIf(a *apl.Apl, LO, RO apl.Value) apl.Function {
+	p := LO.(apl.Function)
+	f := RO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		var l apl.Value
+		if L != nil {
+			l = L.Copy()
+		}
+		v, err := p.Call(a, l, R.Copy())
+		if err != nil {
+			return nil, unhandled(err)
+		}
+		b, ok := predicate(a, v)
+		if ok == false {
+			return nil, fmt.Errorf("if: predicate must return a boolean scalar: %s", v.String(a.Format))
+		}
+		if b == false {
+			return nil, errCondition
+		}
+		v, err = f.Call(a, L, R)
+		return v, unhandled(err)
+	}
+	return function(derived)
+}
+
+// condElse calls g, if f is a conditional p⍰h and the predicate is false.
+func condElse(a *apl.Apl, LO, RO apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	g := RO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		v, err := f.Call(a, L, R)
+		if err == errCondition {
+			v, err = g.Call(a, L, R)
+			return v, unhandled(err)
+		}
+		return v, err
+	}
+	return function(derived)
+}
@@ -0,0 +1,62 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⍡",
+		Domain:  MonadicOp(Function(nil)),
+		doc:     "deep each, apply to all leaves of nested lists",
+		derived: deepEach,
+	})
+}
+
+// deepEach applies f to each simple scalar in a nested structure.
+// Lists are descended recursively and the structure is preserved.
+// Arrays in the leaves are handled like each.
+// Called dyadically, L and R are descended in parallel if both are lists,
+// otherwise the non-list argument is paired with every element of the other.
+//	-⍡(1;(2;3 4;);)      ⍝ (¯1;(¯2;¯3 ¯4;);)
+func deepEach(a *apl.Apl, LO, _ apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	var derived func(a *apl.Apl, L, R apl.Value) (apl.Value, error)
+	derived = func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		ll, lok := L.(apl.List)
+		rl, rok := R.(apl.List)
+		if lok == false && rok == false {
+			if L == nil {
+				return each1(a, R, f)
+			}
+			return each2(a, L, R, f)
+		}
+
+		n := len(rl)
+		if rok == false {
+			n = len(ll)
+		} else if lok && len(ll) != len(rl) {
+			return nil, fmt.Errorf("deep each: list lengths differ: %d %d", len(ll), len(rl))
+		}
+		res := make(apl.List, n)
+		for i := range res {
+			l, r := L, R
+			if lok {
+				l = ll[i]
+			}
+			if rok {
+				r = rl[i]
+			}
+			v, err := derived(a, l, r)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = v
+		}
+		return res, nil
+	}
+	return function(derived)
+}
@@ -41,6 +41,9 @@ func each1(a *apl.Apl, R apl.Value, f apl.Function) (apl.Value, error) {
 	if c, ok := R.(apl.Channel); ok {
 		return eachChannel(a, nil, c, f)
 	}
+	if d, ok := R.(*apl.Dict); ok {
+		return eachDict(a, d, f)
+	}
 
 	ar, ok := R.(apl.Array)
 	if ok {
@@ -57,6 +60,9 @@ func each1(a *apl.Apl, R apl.Value, f apl.Function) (apl.Value, error) {
 
 	res := apl.NewMixed(apl.CopyShape(ar))
 	for i := range res.Values {
+		if err := a.Interrupted(); err != nil {
+			return nil, err
+		}
 		v, err := f.Call(a, nil, ar.At(i))
 		if err != nil {
 			return nil, err
@@ -72,6 +78,9 @@ func each1(a *apl.Apl, R apl.Value, f apl.Function) (apl.Value, error) {
 func eachList(a *apl.Apl, l apl.List, f apl.Function) (apl.Value, error) {
 	res := make(apl.List, len(l))
 	for i := range res {
+		if err := a.Interrupted(); err != nil {
+			return nil, err
+		}
 		v, err := f.Call(a, nil, l[i])
 		if err != nil {
 			return nil, err
@@ -81,6 +90,25 @@ func eachList(a *apl.Apl, l apl.List, f apl.Function) (apl.Value, error) {
 	return res, nil
 }
 
+// eachDict applies f to each value of a dict.
+// For a dict of vectors, this is a function per column.
+func eachDict(a *apl.Apl, d *apl.Dict, f apl.Function) (apl.Value, error) {
+	keys := d.Keys()
+	res := apl.Dict{K: make([]apl.Value, len(keys)), M: make(map[apl.Value]apl.Value)}
+	for i, k := range keys {
+		if err := a.Interrupted(); err != nil {
+			return nil, err
+		}
+		v, err := f.Call(a, nil, d.At(k))
+		if err != nil {
+			return nil, err
+		}
+		res.K[i] = k.Copy()
+		res.M[k.Copy()] = v.Copy()
+	}
+	return &res, nil
+}
+
 // EachChannel returns a channel and applies the function f to each value in the input channel.
 // The result is written to the output channel.
 // If f returns an EmptyArray, no output value is written.
@@ -169,6 +197,9 @@ func each2(a *apl.Apl, L, R apl.Value, f apl.Function) (apl.Value, error) {
 
 	res := apl.NewMixed(shape)
 	for i := range res.Values {
+		if err := a.Interrupted(); err != nil {
+			return nil, err
+		}
 		if rok == true {
 			rv = ar.At(i)
 		}
@@ -202,6 +233,9 @@ func eachList2(a *apl.Apl, L, R apl.Value, f apl.Function) (apl.Value, error) {
 
 	res := make(apl.List, size)
 	for i := range res {
+		if err := a.Interrupted(); err != nil {
+			return nil, err
+		}
 		lv := L
 		rv := R
 		if lok {
@@ -0,0 +1,84 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⍢",
+		Domain:  DyadicOp(Split(Function(nil), nil)),
+		doc:     "fold with seed",
+		derived: fold,
+	})
+}
+
+// fold reduces R from the left, starting with the seed given as the right operand.
+// The accumulator is the left argument of f, each item of R the right argument.
+// Items are the elements of a list or the major cells of an array.
+// If R is empty, the result is the seed.
+//	-⍢0⊢1 2 3         ⍝ ((0-1)-2)-3 = ¯6
+//	{⍺,2×⍵}⍢(⍳0)⊢1 2 3  ⍝ 2 4 6
+func fold(a *apl.Apl, LO, RO apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		if L != nil {
+			return nil, fmt.Errorf("fold cannot be called dyadically")
+		}
+		if _, ok := RO.(apl.Function); ok {
+			return nil, fmt.Errorf("fold: seed must be a value")
+		}
+		acc := RO.Copy()
+		var err error
+		for _, v := range items(a, R) {
+			acc, err = f.Call(a, acc, v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return acc, nil
+	}
+	return function(derived)
+}
+
+// items splits R into a list of its items.
+// These are the elements of a list, or the major cells of an array.
+// A scalar is a single item.
+func items(a *apl.Apl, R apl.Value) []apl.Value {
+	switch v := R.(type) {
+	case apl.List:
+		res := make([]apl.Value, len(v))
+		for i := range v {
+			res[i] = v[i].Copy()
+		}
+		return res
+	case apl.EmptyArray:
+		return nil
+	case apl.Array:
+		shape := v.Shape()
+		if len(shape) == 0 || shape[0] == 0 {
+			return nil
+		}
+		res := make([]apl.Value, shape[0])
+		if len(shape) == 1 {
+			for i := range res {
+				res[i] = v.At(i).Copy()
+			}
+			return res
+		}
+		n := v.Size() / shape[0]
+		for i := range res {
+			m := apl.NewMixed(apl.CopyShape(v)[1:])
+			for k := range m.Values {
+				m.Values[k] = v.At(i*n + k).Copy()
+			}
+			res[i] = a.UnifyArray(m)
+		}
+		return res
+	default:
+		return []apl.Value{R.Copy()}
+	}
+}
@@ -0,0 +1,78 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⌾",
+		Domain:  MonadicOp(Function(nil)),
+		doc:     "generate, build array from index function",
+		derived: generate,
+	})
+}
+
+// generate builds an array of shape R by calling f for each index.
+// For a vector, f is called with the scalar index, otherwise
+// with the index vector. Indexes respect ⎕IO, as for ⍳.
+// If L is given, it is passed as the left argument to f.
+// Each call of f must return a scalar.
+//	{⍵*2}⌾4          ⍝ 1 4 9 16
+//	{+/⍵}⌾2 3        ⍝ 2 3 4, 3 4 5
+func generate(a *apl.Apl, LO, RO apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		to := ToIndexArray(nil)
+		v, ok := to.To(a, R)
+		if ok == false {
+			return nil, fmt.Errorf("generate: R must be a shape vector: %T", R)
+		}
+		if _, ok := v.(apl.EmptyArray); ok {
+			return apl.EmptyArray{}, nil
+		}
+		ia := v.(apl.IntArray)
+		if s := ia.Shape(); len(s) != 1 {
+			return nil, fmt.Errorf("generate: R must be a shape vector: rank %d", len(s))
+		}
+		shape := make([]int, len(ia.Ints))
+		for i, n := range ia.Ints {
+			if n < 0 {
+				return nil, fmt.Errorf("generate: shape must not be negative")
+			}
+			shape[i] = n
+		}
+		if apl.Prod(shape) == 0 {
+			return apl.EmptyArray{}, nil
+		}
+
+		res := apl.NewMixed(shape)
+		idx := make([]int, len(shape))
+		for i := range res.Values {
+			var x apl.Value
+			if len(idx) == 1 {
+				x = apl.Int(idx[0] + a.Origin)
+			} else {
+				iv := apl.IntArray{Dims: []int{len(idx)}, Ints: make([]int, len(idx))}
+				for k := range idx {
+					iv.Ints[k] = idx[k] + a.Origin
+				}
+				x = iv
+			}
+			v, err := f.Call(a, L, x)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := v.(apl.Array); ok {
+				return nil, fmt.Errorf("generate: result must be a scalar")
+			}
+			res.Values[i] = v.Copy()
+			apl.IncArrayIndex(idx, shape)
+		}
+		return a.UnifyArray(res), nil
+	}
+	return function(derived)
+}
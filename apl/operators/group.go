@@ -0,0 +1,160 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⌸",
+		Domain:  MonadicOp(Function(nil)),
+		doc:     "group by",
+		derived: groupBy,
+	})
+}
+
+// groupBy applies the key function f to each item of R
+// and returns a dictionary that maps each key to the items with that key.
+// Keys are in order of their first appearance, as by unique.
+// The values are sub-arrays of R (or lists, if R is a list).
+//	(2∘|)⌸⍳6                           ⍝ 1:1 3 5, 0:2 4 6
+//	{⍵[1]}⌸('apple';'avocado';'bean';)
+//
+// Called dyadically, R is a dict of vectors (or a table) and L the name of a key column.
+// The rows are grouped by the key column and f reduces each group of the other columns,
+// similar to SQL GROUP BY:
+//	`cat (+/)⌸ `cat`qty#(`a`b`a;1 2 3;)  ⍝ cat:a b, qty: 4 2
+func groupBy(a *apl.Apl, LO, _ apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		if L != nil {
+			return groupFrame(a, f, L, R)
+		}
+		values := items(a, R)
+		keys := make([]apl.Value, len(values))
+		for i, v := range values {
+			k, err := f.Call(a, nil, v)
+			if err != nil {
+				return nil, err
+			}
+			if ar, ok := k.(apl.Array); ok {
+				if ar.Size() != 1 {
+					return nil, fmt.Errorf("group by: key must be a scalar: %s", k.String(a.Format))
+				}
+				k = ar.At(0)
+			}
+			keys[i] = k
+		}
+		d := &apl.Dict{M: make(map[apl.Value]apl.Value)}
+		if len(keys) == 0 {
+			return d, nil
+		}
+
+		uniq, masks, err := groups(a, keys)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < uniq.Size(); i++ {
+			key := uniq.At(i)
+			mask := masks[i]
+			var group apl.Value
+			if l, ok := R.(apl.List); ok {
+				var g apl.List
+				for k := range l {
+					if mask.Ints[k] == 1 {
+						g = append(g, l[k].Copy())
+					}
+				}
+				group = g
+			} else if group, err = Replicate(a, mask, R, 0); err != nil {
+				return nil, err
+			}
+			d.K = append(d.K, key)
+			d.M[key] = group
+		}
+		return d, nil
+	}
+	return function(derived)
+}
+
+// groupFrame groups the rows of a dict of vectors by the column L
+// and applies f to the groups of each other column.
+func groupFrame(a *apl.Apl, f apl.Function, L, R apl.Value) (apl.Value, error) {
+	t, istable := R.(apl.Table)
+	d, ok := R.(*apl.Dict)
+	if istable {
+		d = t.Dict
+	} else if ok == false {
+		return nil, fmt.Errorf("group by: right argument must be a dict: %T", R)
+	}
+	if _, ok := L.(apl.String); ok == false {
+		return nil, fmt.Errorf("group by: left argument must be a key column: %T", L)
+	}
+	kc, ok := d.At(L).(apl.Array)
+	if ok == false || len(kc.Shape()) != 1 {
+		return nil, fmt.Errorf("group by: key column must be a vector: %s", L.String(a.Format))
+	}
+	keys := make([]apl.Value, kc.Size())
+	for i := range keys {
+		keys[i] = kc.At(i)
+	}
+
+	res := apl.Dict{M: make(map[apl.Value]apl.Value)}
+	if len(keys) == 0 {
+		return &res, nil
+	}
+	uniq, masks, err := groups(a, keys)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range d.Keys() {
+		var col apl.Value = uniq
+		if k != L {
+			v := apl.MixedArray{Dims: []int{len(masks)}, Values: make([]apl.Value, len(masks))}
+			for i, mask := range masks {
+				g, err := Replicate(a, mask, d.At(k), 0)
+				if err != nil {
+					return nil, err
+				}
+				if v.Values[i], err = f.Call(a, nil, g); err != nil {
+					return nil, err
+				}
+			}
+			col = a.UnifyArray(v)
+		}
+		res.K = append(res.K, k.Copy())
+		res.M[k.Copy()] = col
+	}
+	if istable {
+		return dict2table(a, &res)
+	}
+	return &res, nil
+}
+
+// groups returns the unique keys and a mask for the items of each key.
+func groups(a *apl.Apl, keys []apl.Value) (apl.Array, []apl.IntArray, error) {
+	u, err := apl.Primitive("∪").Call(a, nil, a.UnifyArray(apl.MixedArray{Dims: []int{len(keys)}, Values: keys}))
+	if err != nil {
+		return nil, nil, err
+	}
+	uniq := u.(apl.Array)
+	masks := make([]apl.IntArray, uniq.Size())
+	for i := range masks {
+		key := uniq.At(i)
+		mask := apl.IntArray{Dims: []int{len(keys)}, Ints: make([]int, len(keys))}
+		for k := range keys {
+			m, err := apl.Primitive("≡").Call(a, keys[k], key)
+			if err != nil {
+				return nil, nil, err
+			}
+			if m == apl.Bool(true) {
+				mask.Ints[k] = 1
+			}
+		}
+		masks[i] = mask
+	}
+	return uniq, masks, nil
+}
@@ -23,5 +23,19 @@ func identityItem(f apl.Value) apl.Value {
 			return numbers.Float(math.MaxFloat64)
 		}
 	}
+	// Derived functions that reduce to their operand for scalar arguments.
+	if d, ok := f.(operands); ok {
+		switch op, lo, ro := d.Operands(); op {
+		case "⍨":
+			return identityItem(lo) // L f⍨ R is R f L
+		case ".":
+			return identityItem(ro) // L f.g R is L g R for scalars, also for ∘.g
+		}
+	}
 	return nil
 }
+
+// operands is implemented by derived functions.
+type operands interface {
+	Operands() (string, apl.Value, apl.Value)
+}
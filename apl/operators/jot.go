@@ -16,6 +16,14 @@ func init() {
 	})
 }
 
+// compose combines two functions, or binds an argument to a dyadic function.
+//	f∘g R     Form I:   f g R
+//	A∘g R     Form II:  A g R, left bind (curry)
+//	(f∘X) R   Form III: R f X, right bind
+//	L f∘g R   Form IV:  L f g R
+// The bound forms II and III are monadic functions.
+//	(2∘-)5    ⍝ ¯3
+//	(-∘2)5    ⍝ 3
 func compose(a *apl.Apl, f, g apl.Value) apl.Function {
 	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 		fn, isfunc := f.(apl.Function)
@@ -43,6 +51,8 @@ func compose(a *apl.Apl, f, g apl.Value) apl.Function {
 					return nil, err
 				}
 				return fn.Call(a, L, v.Copy())
+			} else if isfunc || isgunc {
+				return nil, fmt.Errorf("compose: function with bound argument cannot be called dyadically")
 			}
 		}
 		return nil, fmt.Errorf("compose: cannot handle %T %T ∘ %T %T", L, f, g, R)
@@ -0,0 +1,59 @@
+package operators
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⍥",
+		Domain:  MonadicOp(Function(nil)),
+		doc:     "memoize",
+		derived: memoize,
+	})
+}
+
+// memoize caches the results of f keyed by its arguments.
+// F should be a pure function.
+// The cache is kept for each function value by the interpreter, see apl.MemoCache.
+// Recursive functions must call themselves by name to use the cache:
+//	fib←{⍵<2:⍵ ⋄ (fib ⍵-1)+fib ⍵-2}⍥
+func memoize(a *apl.Apl, LO, _ apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		// Derived functions are not comparable and cannot be cached.
+		if reflect.TypeOf(f).Comparable() == false {
+			return f.Call(a, L, R)
+		}
+		c := a.MemoCache(f)
+		key := memoSerialize(L) + "\x00" + memoSerialize(R)
+		if v, ok := c.Get(key); ok {
+			return v.Copy(), nil
+		}
+
+		v, err := f.Call(a, L, R)
+		if err != nil {
+			return nil, err
+		}
+		c.Set(key, v.Copy())
+		return v, nil
+	}
+	return function(derived)
+}
+
+// memoSerialize returns a canonical string representation of the value.
+// It includes the type and the shape, and uses full precision.
+func memoSerialize(v apl.Value) string {
+	if v == nil {
+		return ""
+	}
+	var shape []int
+	if ar, ok := v.(apl.Array); ok {
+		shape = ar.Shape()
+	}
+	return fmt.Sprintf("%T%v%s", v, shape, v.String(apl.Format{PP: -1}))
+}
@@ -16,12 +16,32 @@ func init() {
 	})
 }
 
-// TODO: should there be a limit? How to set/change it?
-const powerlimit = 1000
+// PowerLimit is the default maximum number of iterations for the power operator,
+// if the right operand is a function.
+var PowerLimit = 1000
 
+// power applies f n times, if the right operand is an integer n.
+// If it is a function g, f is applied until (f R) g R returns 1.
+// The number of iterations is bounded by PowerLimit, or by n if the right operand is a list (g;n;).
+// For a fixed point iteration with ⍣= or ⍣≡, the iteration is stopped with a cycle error,
+// if a value repeats without converging.
+// A user defined g may see repeated values, e.g. {?6}⍣{⍺=6}1.
+//	1+∘÷⍣=1           ⍝ fixed point
+//	1+∘÷⍣(=;20;)1     ⍝ with at most 20 iterations
 func power(a *apl.Apl, f, g apl.Value) apl.Function {
 	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 		f := f.(apl.Function)
+		limit := PowerLimit
+		if l, ok := g.(apl.List); ok {
+			if len(l) != 2 {
+				return nil, fmt.Errorf("power: list RO must be (function;limit;)")
+			}
+			n, ok := ToIndex(nil).To(a, l[1])
+			if ok == false || n.(apl.Int) < 1 {
+				return nil, fmt.Errorf("power: iteration limit must be a positive integer: %s", l[1].String(a.Format))
+			}
+			g, limit = l[0], int(n.(apl.Int))
+		}
 		gn, isgf := g.(apl.Function)
 		to := ToIndex(nil)
 		if isgf == false {
@@ -39,6 +59,9 @@ func power(a *apl.Apl, f, g apl.Value) apl.Function {
 			var err error
 			v := R
 			for i := 0; i < n; i++ {
+				if err := a.Interrupted(); err != nil {
+					return nil, err
+				}
 				v, err = f.Call(a, L, v)
 				if err != nil {
 					return nil, err
@@ -51,11 +74,18 @@ func power(a *apl.Apl, f, g apl.Value) apl.Function {
 			var fR, v apl.Value
 			r := R
 			m := 0
+			var seen map[string]int
+			if p, ok := gn.(apl.Primitive); ok && (p == "=" || p == "≡") {
+				seen = map[string]int{memoSerialize(R): 0}
+			}
 			for {
-				if m > powerlimit {
-					return nil, fmt.Errorf("power: recusion limit exceeded")
+				if m >= limit {
+					return nil, fmt.Errorf("power: iteration limit exceeded: %d", limit)
 				}
 				m++
+				if err := a.Interrupted(); err != nil {
+					return nil, err
+				}
 				fR, err = f.Call(a, L, r)
 				if err != nil {
 					return nil, err
@@ -74,7 +104,13 @@ func power(a *apl.Apl, f, g apl.Value) apl.Function {
 				if n == 1 {
 					return r, nil
 				}
-
+				if seen != nil {
+					k := memoSerialize(r)
+					if i, ok := seen[k]; ok {
+						return nil, fmt.Errorf("power: cycle detected: period %d after %d iterations", m-i, m)
+					}
+					seen[k] = m
+				}
 			}
 		}
 	}
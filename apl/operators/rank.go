@@ -297,7 +297,7 @@ func sendParseSubArray(a *apl.Apl, rank int, in apl.Channel) (apl.Value, error)
 			if err == io.EOF || err == io.ErrClosedPipe {
 				return
 			} else if err != nil {
-				out[0] <- apl.Error{err}
+				out[0] <- apl.Error{E: err}
 				return
 			}
 			select {
@@ -350,16 +350,25 @@ func Take(a *apl.Apl, ai apl.IntArray, ar apl.Array, x []int) (apl.Array, error)
 		}
 	}
 
-	res := apl.MakeArray(ar, shape)
-	var z apl.Value
-	if u, ok := res.(apl.Uniform); ok {
-		z = u.Zero()
-	} else {
-		z = apl.Int(0)
+	// The fill element of a view is the zero of its parent.
+	// A view without fill elements (e.g. a transposition) has a nil Fill.
+	var z apl.Value = apl.Int(0)
+	u := ar
+	if v, ok := ar.(apl.View); ok {
+		u = v.Parent
+		if v.Fill != nil {
+			z = v.Fill
+		}
+	}
+	if zu, ok := u.(apl.Uniform); ok {
+		z = zu.Zero()
 	}
+
+	// The result references elements of ar, -1 marks fill elements.
+	index := make([]int, apl.Prod(shape))
 	idx := make([]int, len(shape))
 	ic, src := apl.NewIdxConverter(ar.Shape())
-	for i := 0; i < res.Size(); i++ {
+	for i := range index {
 		copy(src, idx)
 		zero := false
 		for k, n := range off {
@@ -369,11 +378,11 @@ func Take(a *apl.Apl, ai apl.IntArray, ar apl.Array, x []int) (apl.Array, error)
 			}
 		}
 		if zero {
-			res.Set(i, z)
+			index[i] = -1
 		} else {
-			res.Set(i, ar.At(ic.Index(src)).Copy())
+			index[i] = ic.Index(src)
 		}
 		apl.IncArrayIndex(idx, shape)
 	}
-	return res, nil
+	return apl.NewView(ar, shape, index, z), nil
 }
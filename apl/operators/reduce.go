@@ -2,9 +2,11 @@ package operators
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/ktye/iv/apl"
 	. "github.com/ktye/iv/apl/domain"
+	"github.com/ktye/iv/apl/numbers"
 )
 
 func init() {
@@ -109,6 +111,58 @@ func reduction(a *apl.Apl, f apl.Value, axis int) apl.Function {
 	return function(derived)
 }
 
+// reduceBits is a fast path for reducing a packed boolean vector
+// with ∧ ∨ + or ≠. It returns false for other functions.
+func reduceBits(f apl.Function, b apl.BitArray) (apl.Value, bool) {
+	p, ok := f.(apl.Primitive)
+	if ok == false {
+		return nil, false
+	}
+	switch p {
+	case "∧":
+		return apl.Bool(b.Count() == b.Size()), true
+	case "∨":
+		return apl.Bool(b.Count() > 0), true
+	case "+":
+		return apl.Int(b.Count()), true
+	case "≠":
+		return apl.Bool(b.Count()%2 == 1), true
+	}
+	return nil, false
+}
+
+// reduceFloats is a fast path for reducing a float vector
+// with + × ⌊ or ⌈. It reduces from the right, as the general case.
+func reduceFloats(f apl.Function, x numbers.FloatArray) (apl.Value, bool) {
+	p, ok := f.(apl.Primitive)
+	if ok == false || len(x.Floats) == 0 {
+		return nil, false
+	}
+	v := x.Floats
+	r := v[len(v)-1]
+	switch p {
+	case "+":
+		for i := len(v) - 2; i >= 0; i-- {
+			r = v[i] + r
+		}
+	case "×":
+		for i := len(v) - 2; i >= 0; i-- {
+			r = v[i] * r
+		}
+	case "⌊":
+		for i := len(v) - 2; i >= 0; i-- {
+			r = math.Min(v[i], r)
+		}
+	case "⌈":
+		for i := len(v) - 2; i >= 0; i-- {
+			r = math.Max(v[i], r)
+		}
+	default:
+		return nil, false
+	}
+	return numbers.Float(r), true
+}
+
 func reduct(a *apl.Apl, f apl.Function, l, r apl.Value, axis int) (apl.Value, error) {
 
 	if c, ok := r.(apl.Channel); ok {
@@ -119,6 +173,17 @@ func reduct(a *apl.Apl, f apl.Function, l, r apl.Value, axis int) (apl.Value, er
 		return reduceChannel(a, l, f, c)
 	}
 
+	if b, ok := r.(apl.BitArray); ok && l == nil && len(b.Dims) == 1 {
+		if v, ok := reduceBits(f, b); ok {
+			return v, nil
+		}
+	}
+	if x, ok := r.(numbers.FloatArray); ok && l == nil && len(x.Dims) == 1 {
+		if v, ok := reduceFloats(f, x); ok {
+			return v, nil
+		}
+	}
+
 	if _, ok := r.(apl.Axis); ok {
 		if rr, n, err := splitAxis(a, r); err != nil {
 			return nil, err
@@ -376,7 +441,17 @@ func replicate(a *apl.Apl, L apl.Value, axis int) apl.Function {
 }
 
 // Replicate is the function L over R (L/R) where L and R are arrays.
+// If R is a table or a dict of vectors, it replicates the rows.
 func Replicate(a *apl.Apl, L, R apl.Value, axis int) (apl.Value, error) {
+	if t, ok := R.(apl.Table); ok {
+		d, err := replicateColumns(a, L, t.Dict)
+		if err != nil {
+			return nil, err
+		}
+		return dict2table(a, d)
+	} else if d, ok := R.(*apl.Dict); ok {
+		return replicateColumns(a, L, d)
+	}
 	ai, ar, ax, err := commonReplExp(a, L, R, axis)
 	if err != nil {
 		return nil, fmt.Errorf("replicate: %s", err)
@@ -450,6 +525,26 @@ func Replicate(a *apl.Apl, L, R apl.Value, axis int) (apl.Value, error) {
 	return res, nil
 }
 
+// replicateColumns applies L/ to each column of a dict.
+// All values must be vectors.
+func replicateColumns(a *apl.Apl, L apl.Value, d *apl.Dict) (*apl.Dict, error) {
+	keys := d.Keys()
+	r := apl.Dict{K: make([]apl.Value, len(keys)), M: make(map[apl.Value]apl.Value)}
+	for i, k := range keys {
+		col, ok := d.At(k).(apl.Array)
+		if ok == false || len(col.Shape()) != 1 {
+			return nil, fmt.Errorf("replicate: dict values must be vectors: %s", k.String(a.Format))
+		}
+		v, err := Replicate(a, L, col, 0)
+		if err != nil {
+			return nil, err
+		}
+		r.K[i] = k.Copy()
+		r.M[k.Copy()] = v
+	}
+	return &r, nil
+}
+
 // expand.
 // L is an index array. Only vectors are allowed.
 func expand(a *apl.Apl, L apl.Value, axis int) apl.Function {
@@ -653,6 +748,9 @@ func reduce(a *apl.Apl, vec []apl.Value, d apl.Function) (apl.Value, error) {
 	var err error
 	v := vec[len(vec)-1].Copy()
 	for i := len(vec) - 2; i >= 0; i-- {
+		if err := a.Interrupted(); err != nil {
+			return nil, err
+		}
 		v, err = d.Call(a, vec[i].Copy(), v.Copy())
 		if err != nil {
 			return nil, err
@@ -820,10 +918,7 @@ func nwise(a *apl.Apl, f apl.Function, L, R apl.Value, axis int) (apl.Value, err
 	}
 
 	if n == 0 {
-		var id apl.Value
-		if p, ok := f.(apl.Primitive); ok {
-			id = identityItem(p)
-		}
+		id := identityItem(f.(apl.Value))
 		if id == nil {
 			return nil, fmt.Errorf("n-wise reduction: unknown identify function")
 		}
@@ -885,6 +980,9 @@ func applyNwise(a *apl.Apl, vec []apl.Value, n int, f, g apl.Function) error {
 	reduce := func(x []apl.Value) apl.Value {
 		r := x[len(x)-1]
 		for i := len(x) - 2; i >= 0; i-- {
+			if err = a.Interrupted(); err != nil {
+				return nil
+			}
 			r, err = f.Call(a, x[i], r)
 			if err != nil {
 				return nil
@@ -931,13 +1029,31 @@ func applyNwise(a *apl.Apl, vec []apl.Value, n int, f, g apl.Function) error {
 		}
 	}
 
-	for i := 0; i < len(vec)-n+1; i++ {
-		vec[i] = reduce(vec[i : i+n])
+	_, err = slide(a, vec, vec, n, 1, func(x []apl.Value) (apl.Value, error) {
+		v := reduce(x)
+		return v, err
+	})
+	return err
+}
+
+// slide calls f for each window of n consecutive values of vec, advancing by step.
+// The results are stored in dst, which may be vec itself.
+// It returns the number of windows.
+// It is used by n-wise reduction and by the sliding window operator.
+func slide(a *apl.Apl, dst, vec []apl.Value, n, step int, f func([]apl.Value) (apl.Value, error)) (int, error) {
+	k := 0
+	for i := 0; i+n <= len(vec); i += step {
+		if err := a.Interrupted(); err != nil {
+			return k, err
+		}
+		v, err := f(vec[i : i+n])
 		if err != nil {
-			return err
+			return k, err
 		}
+		dst[k] = v
+		k++
 	}
-	return nil
+	return k, nil
 }
 
 // reduceTack is the derived function from ⊣/ or ⊢/ .
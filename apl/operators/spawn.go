@@ -0,0 +1,46 @@
+package operators
+
+import (
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "&",
+		Domain:  MonadicOp(Function(nil)),
+		doc:     "spawn, call function asynchronously",
+		derived: spawn,
+	})
+}
+
+// spawn calls f in a go routine and returns a channel immediately.
+// The channel receives the single result and is closed.
+// Take (↑C) waits for the result. An error is returned by take.
+// The function is called on a copy of the interpreter,
+// assignments are not visible to the caller.
+//	A←{+/⍳⍵}&1000 ⋄ B←{×/⍳⍵}&10 ⋄ (↑A),↑B
+func spawn(a *apl.Apl, LO, _ apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		b := a.Fork()
+		if L != nil {
+			L = L.Copy()
+		}
+		R = R.Copy()
+		c := apl.NewChannel()
+		go func(c apl.Channel) {
+			defer close(c[0])
+			v, err := f.Call(b, L, R)
+			if err != nil {
+				v = apl.Error{E: err}
+			}
+			select {
+			case <-c[1]:
+			case c[0] <- v:
+			}
+		}(c)
+		return c, nil
+	}
+	return function(derived)
+}
@@ -0,0 +1,91 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⍐",
+		Domain:  MonadicOp(Function(nil)),
+		doc:     "take while",
+		derived: takeWhile,
+	})
+	register(operator{
+		symbol:  "⍗",
+		Domain:  MonadicOp(Function(nil)),
+		doc:     "drop while",
+		derived: dropWhile,
+	})
+}
+
+// takeWhile returns the leading items of R for which the predicate f returns true.
+//	(3∘>)⍐1 2 3 4 1   ⍝ 1 2
+func takeWhile(a *apl.Apl, LO, _ apl.Value) apl.Function {
+	return whileFunc(LO.(apl.Function), "take while", true)
+}
+
+// dropWhile drops the leading items of R for which the predicate f returns true.
+//	(3∘>)⍗1 2 3 4 1   ⍝ 3 4 1
+func dropWhile(a *apl.Apl, LO, _ apl.Value) apl.Function {
+	return whileFunc(LO.(apl.Function), "drop while", false)
+}
+
+func whileFunc(f apl.Function, name string, take bool) apl.Function {
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		if L != nil {
+			return nil, fmt.Errorf("%s cannot be called dyadically", name)
+		}
+		n := 0
+		for _, v := range items(a, R) {
+			p, err := f.Call(a, nil, v)
+			if err != nil {
+				return nil, err
+			}
+			b, ok := predicate(a, p)
+			if ok == false {
+				return nil, fmt.Errorf("%s: predicate must return a boolean scalar: %s", name, p.String(a.Format))
+			}
+			if b == false {
+				break
+			}
+			n++
+		}
+
+		if l, ok := R.(apl.List); ok {
+			if take {
+				return l[:n].Copy(), nil
+			}
+			return l[n:].Copy(), nil
+		} else if _, ok := R.(apl.Array); ok == false {
+			if take == (n == 1) {
+				return R, nil
+			}
+			return apl.EmptyArray{}, nil
+		}
+		if take {
+			return apl.Primitive("↑").Call(a, apl.Int(n), R)
+		}
+		return apl.Primitive("↓").Call(a, apl.Int(n), R)
+	}
+	return function(derived)
+}
+
+// predicate converts the result of a predicate function to a bool.
+func predicate(a *apl.Apl, v apl.Value) (bool, bool) {
+	if ar, ok := v.(apl.Array); ok {
+		if ar.Size() != 1 {
+			return false, false
+		}
+		v = ar.At(0)
+	}
+	n, ok := v.(apl.Number)
+	if ok == false {
+		return false, false
+	}
+	b, ok := a.Tower.ToBool(n)
+	return bool(b), ok
+}
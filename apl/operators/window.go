@@ -0,0 +1,121 @@
+package operators
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(operator{
+		symbol:  "⌻",
+		Domain:  MonadicOp(Function(nil)),
+		doc:     "sliding window",
+		derived: window,
+	})
+}
+
+// window calls f for each sliding window over the items of R.
+// L is the window size, or a pair of window size and step (default 1).
+// Each window is passed to f as an array (or a list, if R is a list).
+// The results are collected in a vector, or in a list if they are not scalars.
+// In contrast to n-wise reduction, f is called once per window and not reduced between items.
+// The windows are visited like in n-wise reduction, see slide.
+//	3 {S←⍵[⍋⍵] ⋄ S[2]}⌻ 5 1 4 2 3   ⍝ sliding median: 4 2 3
+//	2 2 {+/⍵}⌻ ⍳6                    ⍝ 3 7 11
+func window(a *apl.Apl, LO, _ apl.Value) apl.Function {
+	f := LO.(apl.Function)
+	derived := func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+		if L == nil {
+			return nil, fmt.Errorf("sliding window: left argument must be the window size")
+		}
+		v, ok := ToIndexArray(nil).To(a, L)
+		if ok == false {
+			return nil, fmt.Errorf("sliding window: left argument must be an integer or a pair: %T", L)
+		}
+		ai := v.(apl.IntArray)
+		if len(ai.Ints) < 1 || len(ai.Ints) > 2 {
+			return nil, fmt.Errorf("sliding window: left argument must be an integer or a pair")
+		}
+		n, step := ai.Ints[0], 1
+		if len(ai.Ints) == 2 {
+			step = ai.Ints[1]
+		}
+		if n < 1 || step < 1 {
+			return nil, fmt.Errorf("sliding window: size and step must be positive")
+		}
+
+		items, ok := windowItems(R)
+		if ok == false || len(items) < n {
+			return apl.EmptyArray{}, nil
+		}
+		res := make([]apl.Value, (len(items)-n)/step+1)
+		_, err := slide(a, res, items, n, step, func(x []apl.Value) (apl.Value, error) {
+			return f.Call(a, nil, windowValue(a, R, x))
+		})
+		if err != nil {
+			return nil, err
+		}
+		return listOrArray(a, res), nil
+	}
+	return function(derived)
+}
+
+// windowItems returns the elements of a list or the major cells of an array.
+// It returns false for a scalar.
+func windowItems(R apl.Value) ([]apl.Value, bool) {
+	switch v := R.(type) {
+	case apl.List:
+		return v, true
+	case apl.EmptyArray:
+		return nil, true
+	case apl.Array:
+		shape := v.Shape()
+		if len(shape) == 0 {
+			return nil, false
+		}
+		items := make([]apl.Value, shape[0])
+		size := v.Size() / shape[0]
+		for i := range items {
+			if len(shape) == 1 {
+				items[i] = v.At(i)
+				continue
+			}
+			cell := apl.NewMixed(shape[1:])
+			for k := range cell.Values {
+				cell.Values[k] = v.At(i*size + k)
+			}
+			items[i] = cell
+		}
+		return items, true
+	default:
+		return nil, false
+	}
+}
+
+// windowValue joins the items of a window to a list or an array, depending on R.
+func windowValue(a *apl.Apl, R apl.Value, x []apl.Value) apl.Value {
+	if _, ok := R.(apl.List); ok {
+		w := make(apl.List, len(x))
+		for i := range w {
+			w[i] = x[i].Copy()
+		}
+		return w
+	}
+	dims := apl.CopyShape(R.(apl.Array))
+	dims[0] = len(x)
+	m := apl.NewMixed(dims)
+	size := len(m.Values) / len(x)
+	for i, v := range x {
+		if len(dims) == 1 {
+			m.Values[i] = v.Copy()
+			continue
+		}
+		cell := v.(apl.MixedArray)
+		for k := range cell.Values {
+			m.Values[i*size+k] = cell.Values[k].Copy()
+		}
+	}
+	return a.UnifyArray(m)
+}
@@ -150,6 +150,9 @@ func (p *parser) parseStatement() (item, error) {
 				if t.S == "∘" {
 					i = p.specialJot(i)
 				}
+				if isHybrid(t.S) && p.nothingLeft() {
+					i = item{e: hybrid(t.S), class: verb}
+				}
 				push(i, false)
 			} else {
 				return item{}, fmt.Errorf("unknown symbol: %s", t.S)
@@ -503,6 +506,14 @@ func (p *parser) resolveBrackets() error {
 			}
 			p.setLeft(1, item{e: &d, class: verb})
 			p.removeLeft(0)
+		} else if d, ok := l.e.(*derived); ok && d.op == "←" {
+			// An axis following an assignment arrow is applied to the modifying function.
+			if len(spec) != 1 {
+				return fmt.Errorf("axis must hold a single expression, not %d", len(spec))
+			}
+			d.axis = spec[0]
+			p.setLeft(1, l)
+			p.removeLeft(0)
 		} else if _, ok := l.e.(*derived); ok {
 			// The axis specification following an operator is rewritten as a dyadic operator.
 			// The operator is called "⍂" and as the left operand the axis spec is inserted.
@@ -796,6 +807,11 @@ func (p *parser) specialJot(i item) item {
 	return i
 }
 
+// NothingLeft returns if there are no more tokens on the left side of the current expression.
+func (p *parser) nothingLeft() bool {
+	return len(p.tokens) == 0 || p.tokens[len(p.tokens)-1].T == scan.Endl
+}
+
 // RemoveLeft removes item i from the left side of the stack.
 func (p *parser) removeLeft(l int) {
 	i := len(p.stack) - 1 - l
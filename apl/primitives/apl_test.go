@@ -2,15 +2,30 @@ package primitives
 
 import (
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 	"time"
+	_ "time/tzdata" // time zone tests do not depend on the system database
 
 	"github.com/ktye/iv/apl"
 	"github.com/ktye/iv/apl/big"
 	"github.com/ktye/iv/apl/numbers"
 	"github.com/ktye/iv/apl/operators"
+	apla "github.com/ktye/iv/apl/a"
+	"github.com/ktye/iv/apl/b64"
+	"github.com/ktye/iv/apl/base"
+	"github.com/ktye/iv/apl/bits"
+	"github.com/ktye/iv/apl/cmp"
+	"github.com/ktye/iv/apl/comb"
+	"github.com/ktye/iv/apl/date"
+	"github.com/ktye/iv/apl/dsp"
+	aplfmt "github.com/ktye/iv/apl/fmt"
+	"github.com/ktye/iv/apl/frame"
+	"github.com/ktye/iv/apl/hash"
+	"github.com/ktye/iv/apl/list"
+	"github.com/ktye/iv/apl/set"
 	aplstrings "github.com/ktye/iv/apl/strings"
 	"github.com/ktye/iv/apl/xgo"
 )
@@ -106,6 +121,152 @@ var testCases = []struct {
 	{"⎕PP←1 ⋄ 1.23456789", "1", small},
 	{"⎕PP←3 ⋄ 1.23456789", "1.23", small},
 
+	{"⍝ Decimal separator", "apl/fmt.go", 0},
+	{"⎕DEC", ".", 0},
+	{"⎕DEC←','\n⎕DEC", ",", 0},
+	{"⎕DEC←','\n3,14", "3,14", float},
+	{"⎕DEC←','\nX←3,14 ¯0,5\nX×2", "6,28 ¯1", small},
+	{"⎕DEC←','\n1 ,2", "1 2", 0},
+	{"⎕DEC←','\n1,2 3", "1,2 3", small},
+	{"⎕DEC←','\n1,5J2,5", "1,5J2,5", small},
+	{"⎕DEC←','\n1,2,3", "1,2 3", float},
+	{"⎕DEC←','\n1.5,2", "1,5 2", float},
+	{"⎕DEC←','\n1,5,2,5", "1,5 2,5", float},
+	{"⎕DEC←','\n1.5", "1,5", small},
+	{"⎕DEC←','\n⎕DEC←'.'\n3.14", "3.14", small},
+	{"3,14", "3 14", 0},
+	{"⎕DEC←';'", "fail: illegal decimal separator", 0},
+
+	{"⍝ Thousands separator", "apl/fmt.go", 0},
+	{"⎕GROUP", "", 0},
+	{"1000000", "1000000", 0},
+	{"⎕GROUP←','\n1000000", "1,000,000", 0},
+	{"⎕GROUP←','\n¯1234567 100 1000", "¯1,234,567 100 1,000", 0},
+	{"⎕GROUP←'_'\n⎕PP←10\n1234567.25", "1_234_567.25", float},
+	{"⎕GROUP←'.'\n⎕DEC←','\n⎕PP←10\n¯1234567.25", "¯1.234.567,25", float},
+	{"⎕GROUP←','\n\"1234567\"≡⍕1234567", "1", 0},
+	{"⎕GROUP←','\n⍎⍕1234567", "1,234,567", 0},
+	{"⎕GROUP←','\n2*70", "1,180,591,620,717,411,303,424", rational},
+	{"⎕GROUP←','\n⎕PP←¯1\n1234567", "1234567", 0},
+	{"⎕GROUP←','\n⎕GROUP←''\n1000000", "1000000", 0},
+
+	{"⍝ Rational display", "apl/fmt.go", 0},
+	{"⎕RAT", "1", 0},
+	{"⎕RAT←0\n⎕RAT", "0", 0},
+	{"⎕RAT←2", "fail: illegal value for RAT", 0},
+	{"3÷4", "3r4", rational},
+	{"¯1r3", "¯1r3", rational},
+	{"⎕RAT←0\n3÷4", "0.75", rational},
+	{"⎕RAT←0\n¯1r3", "¯0.333333", rational},
+	{"⎕RAT←0\n6÷3", "2", rational},
+	{"⎕RAT←0\n⎕PP←3\n1r3", "0.333", rational},
+	{"⎕RAT←0\n⎕RAT←1\n3÷4", "3r4", rational},
+
+	{"⍝ Float precision", "apl/tower.go", 0},
+	{"⎕FPC", "53", small},
+	{"⎕FPC←256", "fail: fixed precision", small},
+	{"T←big→set 256\n⎕FPC", "256", small},
+	{"T←big→set 256\n⎕PP←76\n○1", "3.141592653589793238462643383279502884197169399375105820974944592307816406286", small}, // 256 bits are good for 76 digits
+	{"T←big→set 256\nbig→prec ○1", "256", small},
+	{"T←big→set 256\nX←512 big→float 1\nbig→prec X÷3", "512", small},
+	{"T←big→set 256\nX←512 big→float 1\nbig→prec ÷X", "512", small},
+	{"T←big→set 256\nX←512 big→float 1\n⎕PP←100\n⍕○X", "3.141592653589793238462643383279502884197169399375105820974944592307816406286208998628034825342117068", small}, // 512 bits for 100 digits
+	{"T←big→set 256\n⎕FPC←128\n⎕FPC", "128", small},
+	{"T←big→set 256\n⎕FPC←128\nbig→prec 1÷3", "128", small},
+	{"T←big→set 256\n⎕FPC←128\nbig→prec big→float \"0.1\"", "128", small},
+	{"T←big→set 256 ⋄ ⎕FPC←1", "fail: illegal value for FPC", small},
+
+	{"⍝ Compact display of large arrays", "apl/fmt.go", 0},
+	{"⎕ELIDE", "0", 0},
+	{"⎕ELIDE←6 ⋄ ⎕ELIDE", "6", 0},
+	{"⎕ELIDE←¯1", "fail: illegal value", 0},
+	{"⎕ELIDE←6 ⋄ ⍳6", "1 2 3 4 5 6", 0},
+	{"⎕ELIDE←6 ⋄ ⍳7", "1 2 3 ... 5 6 7", 0},
+	{"⎕ELIDE←6 ⋄ ⍳1000000", "1 2 3 ... 999998 999999 1000000", 0},
+	{"⎕ELIDE←5 ⋄ ⍳1000000", "1 2 3 ... 999999 1000000", 0},
+	{"⎕ELIDE←4 ⋄ 'abcdefgh'", "a b ... g h", 0},
+	{"⎕ELIDE←4 ⋄ 2 10⍴⍳20", "1 2 ... 9 10\n11 12 ... 19 20", 0},
+	{"⎕ELIDE←4 ⋄ 10 10⍴⍳100", "1 2 ... 9 10\n11 12 ... 19 20\n...\n81 82 ... 89 90\n91 92 ... 99 100", 0},
+	{"⎕ELIDE←4 ⋄ 5 2 2⍴⍳20", "1 2\n3 4\n\n5 6\n7 8\n\n...\n\n13 14\n15 16\n\n17 18\n19 20", 0},
+	{"⎕ELIDE←4 ⋄ 2 3⍴⍳6", "1 2 3\n4 5 6", 0},
+
+	{"⍝ Output separator and row terminator", "apl/fmt.go", 0},
+	{"⎕SEP←\",\" ⋄ 2 3⍴⍳6", "1,2,3\n4,5,6", 0},
+	{"⎕SEP←\",\" ⋄ ⎕EOL←\";\" ⋄ 2 2 2⍴⍳8", "1,2;3,4;;5,6;7,8", 0},
+	{"⎕EOL←'|' ⋄ 2 3⍴⍳6", "1 2 3|4 5 6", 0},
+	{"⎕SEP←\",\" ⋄ ⎕SEP", ",", 0},
+	{"⎕SEP←\",\" ⋄ ⎕SEP←⍳0 ⋄ 1 2 3", "1 2 3", 0},
+	{"⎕SEP←1", "fail: illegal separator", 0},
+
+	{"⍝ System constants", "apl/var.go", 0},
+	{"⎕A≡'ABCDEFGHIJKLMNOPQRSTUVWXYZ'", "1", 0},
+	{"⎕D≡'0123456789'", "1", 0},
+	{"⍴⎕AV", "256", 0},
+	{"⎕AV[⎕AV⍳'A']", "A", 0},
+	{"(⎕D,6↑⎕A)[1+16 16⊤171]", "A B", 0},
+	{"⎕A←1", "fail: cannot assign to a system constant: ⎕A", 0},
+
+	{"⍝ Null", "apl/null.go", 0},
+	{"⎕NULL", "null", 0},
+	{"⌶⎕NULL", "apl.Null", 0},
+	{"⎕NULL≡⎕NULL", "1", 0},
+	{"⎕NULL≡⍳0", "0", 0},
+	{"⎕NULL∊1 2,⎕NULL", "1", 0},
+	{"⎕NULL∊1 2 3", "0", 0},
+	{"(1 2,⎕NULL)∊⎕NULL", "0 0 1", 0},
+	{"⎕NULL+1", "fail: ", 0},
+	{"⎕NULL←1", "fail: cannot assign to a system constant: ⎕NULL", 0},
+	{"\"json\"⍕`a`b#1,⎕NULL", "{\"a\":1,\"b\":null}", 0},
+	{"\"json\"⍕1 2,⎕NULL", "[1,2,null]", 0},
+
+	{"⍝ Parse json", "apl/json.go", 0},
+	{"D←\"json\"⍎\"{\\\"a\\\":1,\\\"b\\\":null}\" ⋄ D[`b]≡⎕NULL", "1", 0},
+	{"D←\"json\"⍎\"{\\\"a\\\":1,\\\"b\\\":null}\" ⋄ \"json\"⍕D", "{\"a\":1,\"b\":null}", 0},
+	{"\"json\"⍎\"[1,2,3]\"", "1 2 3", 0},
+	{"\"json\"⍎\"[-1,2.5e2,null]\"", "¯1 250 null", small},
+	{"\"json\"⍎\"[[1,2],[3]]\"", "(1 2;3;)", 0},
+	{"\"json\"⍎\"[]\"", "", 0},
+	{"\"json\"⍎\"null\"", "null", 0},
+	{"\"json\"⍎\"[1,2\"", "fail: parse json: unexpected EOF", 0},
+
+	{"⍝ Packed boolean arrays", "apl/bitarray.go", 0},
+	{"⌶(5000⍴1 0)=1", "apl.BitArray", small},
+	{"⌶1 0 1=1", "apl.BoolArray", small},
+	{"+/(5000⍴1 0)=1", "2500", 0},
+	{"∧/(5000⍴1)=1", "1", 0},
+	{"∨/(5000⍴0)=1", "0", 0},
+	{"3↑(5000⍴1 0)=1", "1 0 1", 0},
+
+	{"⍝ Float arrays", "apl/primitives/floats.go", 0},
+	{"1.5 2.5+0.5 1", "2 3.5", small},
+	{"1.5 2.5-1 2", "0.5 0.5", small},
+	{"1 2×1.5 2.5", "1.5 5", small},
+	{"2×1.5 2.5", "3 5", small},
+	{"1.5 2.5×2", "3 5", small},
+	{"1.5 ¯2.5⌊1 0", "1 ¯2.5", small},
+	{"1.5 ¯2.5⌈1 0", "1.5 0", small},
+	{"⌶1.5 2.5+1 2", "numbers.FloatArray", small},
+	{"⌶1 2+1 2", "apl.IntArray", small},
+	{"+/1.5 2.5 3", "7", small},
+	{"×/1.5 2.5 2", "7.5", small},
+	{"⌊/1.5 ¯2.5 3", "¯2.5", small},
+	{"⌈/1.5 ¯2.5 3", "3", small},
+	{"-/1.5 2.5 3", "2", small},
+	{"+/(1+⍳1000)÷2", "250750", small},
+
+	{"⍝ Lazy views for take, drop and transpose", "apl/view.go", 0},
+	{"⌶⍉100 50⍴⍳5000", "apl.View", 0},
+	{"⌶⍉10 5⍴⍳50", "apl.IntArray", small},
+	{"⌶⍉⍉100 50⍴⍳5000", "apl.View", 0},
+	{"M←100 50⍴⍳5000 ⋄ A←⍉M ⋄ A[1;2]←0 ⋄ M[2;1] ⋄ A[1;2]", "51\n0", 0},
+	{"M←100 50⍴⍳5000 ⋄ A←2↓M ⋄ A[1;1]←0 ⋄ M[3;1] ⋄ A[1;1]", "101\n0", 0},
+	{"M←100 50⍴⍳5000 ⋄ (M[1;1]←99){⍵[1;1]}⍉M", "1", 0},
+	{"⍴¯2 ¯3↑2↓⍉100 50⍴⍳5000", "2 3", 0},
+	{"¯2 ¯3↑2↓⍉100 50⍴⍳5000", "4899 4949 4999\n4900 4950 5000", 0},
+	{"+/,120 60↑⍉100 50⍴⍳5000", "4501500", 0},
+	{"+/,⍉120 60↑100 50⍴⍳5000", "12502500", 0},
+	{"+/,¯120 ¯60↑80 70↑100 50⍴⍳5000", "6417600", 0},
+
 	{"⍝ Type, typeof", "apl/primitives/type.go", 0},
 	{"⌶'a'", "apl.String", 0},
 
@@ -191,6 +352,33 @@ var testCases = []struct {
 	{"1 2 3∪5 3 2 1 4", "1 2 3 5 4", 0},
 	{"5 6 7∪1 2 3", "5 6 7 1 2 3", 0},
 
+	{"⍝ Sorted sets", "apl/set/set.go", 0},
+	{"set→unique 22 10 22 22 21 10 5 10", "5 10 21 22", 0},
+	{"set→unique 'MISSISSIPPI'", "I M P S", 0},
+	{"set→unique 3", "3", 0},
+	{"set→unique ⍳0", "", 0},
+	{"5 6 7 set→union 1 2 3 6", "1 2 3 5 6 7", 0},
+	{"(5 6 7 set→union 1 2 3)≡1 2 3 set→union 7 6 5", "1", 0},
+	{"3 1 4 1 5 set→inter 5 9 2 6 5 3", "3 5", 0},
+	{"3 1 4 1 5 set→inter 2 6", "", 0},
+	{"3 1 4 1 5 9 set→diff 1 5", "3 4 9", 0},
+	{"`c`a`b set→union `b`d", "a b c d", 0},
+	{"set→union 1 2", "fail: union: cannot be called monadically", 0},
+
+	{"⍝ Bags", "apl/set/bag.go", 0},
+	{"1 1 2 set→bagunion 1 2 2 3", "1 1 2 2 3", 0},
+	{"1 1 2 set→baginter 1 2 2 3", "1 2", 0},
+	{"1 1 2 set→bagdiff 1 2 2 3", "1", 0},
+	{"3 1 3 2 3 set→bagdiff 3 3", "1 2 3", 0},
+	{"3 1 3 2 3 set→baginter 2 3 3 4", "3 3 2", 0},
+	{"'MISSISSIPPI' set→bagdiff 'SIP'", "M S I S S I P I", 0},
+	{"'ABBA' set→bagunion 'BAB'", "A B B A", 0},
+	{"1 2 set→bagdiff 1 2", "", 0},
+	{"⍴1 2 set→bagdiff 1 2", "0", 0},
+	{"3 set→bagunion 3", "3", 0},
+	{"(⍳0) set→bagunion 1 1", "1 1", 0},
+	{"(2 2⍴1) set→bagdiff 1", "fail: bagdiff: argument must be a vector", 0},
+
 	{"⍝ Find", "apl/primitives/find.go", 0},
 	{"'AN'⍷'BANANA'", "0 1 0 1 0 0", 0},
 	{"'ANA'⍷'BANANA'", "0 1 0 1 0 0", 0},
@@ -198,6 +386,15 @@ var testCases = []struct {
 	{"(2 2⍴5 6 8 9)⍷3 3⍴⍳9", "0 0 0\n0 1 0\n0 0 0", 0},
 	{"4 5 6⍷3 3⍴⍳9", "0 0 0\n1 0 0\n0 0 0", 0},
 
+	{"⍝ Identity, complex conjugate", "apl/primitives/elementary.go", 0},
+	{"+5", "5", 0},
+	{"+¯2.5", "¯2.5", small},
+	{"+3J4", "3J¯4", float},
+	{"+3J¯4", "3J4", float},
+	{"+3J4 1J¯2 0J1", "3J¯4 1J2 0J¯1", float},
+	{"9○3J4×+3J4", "25", small},
+	{"++3J4", "3J4", float},
+
 	{"⍝ Magnitude, Residue, Ceil, Floor, Min, Max", "apl/primitives/elementary.go", 0},
 	{"|1 ¯2 ¯3.2 2.2a20", "1 2 3.2 2.2", float},                  // magnitude
 	{"3 3 ¯3 ¯3|¯5 5 ¯4 4", "1 2 ¯1 ¯2", 0},                      // residue
@@ -259,6 +456,47 @@ var testCases = []struct {
 	{"3≢1⍴3", "1", 0},               // not match
 	{`""≢⍳0`, "1", 0},               // not match
 
+	{"(1;(2;3.0;);)≡(1.0;(2;3;);)", "1", 0},       // nested match with mixed int/float leaves
+	{"(1 2;(3.0;4;);)≡(1.0 2.0;(3;4;);)", "1", 0}, // nested match with vectors
+	{"(1;(2;3;);)≡(1;(2;3.5;);)", "0", 0},         // nested leaves differ
+	{"(1;(2;3;);)≡(1;(2;);3;)", "0", 0},           // structure differs
+	{"(1;(2;3;);)≡(1;(2;3;4;);)", "0", 0},         // nested shape differs
+
+	{"⍝ Tolerant match", "apl/primitives/match.go", 0},
+	{"1 2 3≡1 2 3+1E¯15", "0", 0},                    // match is exact
+	{"1 2 3≅1 2 3+1E¯15", "1", 0},                    // tolerant match with the default ⎕CT
+	{"(÷3)≅1-2÷3", "1", 0},                           //
+	{"1≅1.001", "0", 0},                              //
+	{"⎕CT←0.01⋄1≅1.001", "1", 0},                     // the tolerance is relative
+	{"⎕CT←0.01⋄1000≅1001", "1", 0},                   //
+	{"⎕CT←0⋄1≅1+1E¯15", "0", 0},                      // zero tolerance is exact
+	{"⎕CT←0.01\nT←big→set 256\n1≅1.001", "1", small}, // the tolerance is converted to the new tower
+	{"0≅1E¯20", "0", 0},                              // zero only matches zero
+	{"1≅∞", "0", float},                              // infinities match exactly
+	{"1E300≅∞", "0", float},                          //
+	{"∞≅¯∞", "0", float},                             //
+	{"∞ ¯∞≅∞ ¯∞", "1", float},                        //
+	{"(1;(2;3;);)≅(1+1E¯15;(2;3-1E¯15;);)", "1", 0},  // nested
+	{"1 2≅1 2 3", "0", 0},                            // shapes must match
+	{"'abc'≅'abc'", "1", 0},                          //
+	{"0J1≅0J1+1E¯15", "1", float},                    //
+	{"⎕CT", "1E¯14", small},                          //
+	{"⎕CT←¯1", "fail: illegal value for CT: ¯1", 0},  //
+
+	{"⍝ Diff", "apl/cmp/diff.go", 0},
+	{"D←1 2 3 4 5 cmp→diff 1 2 3 4 5⋄⍴D[`index]", "0", 0},
+	{"1 2 3 4 5 cmp→diff 1 2 9 4 6", "index left right\n3 3 9\n5 5 6", 0},
+	{"D←1 2 3 4 5 cmp→diff 1 2 9 4 6⋄D[`index]", "3 5", 0},
+	{"⎕IO←0⋄D←1 2 3 4 5 cmp→diff 1 2 9 4 6⋄D[`index]", "2 4", 0},
+	{"D←(2 2⍴⍳4) cmp→diff 2 2⍴1 9 3 5⋄D[`index]", "(1 2;2 2;)", 0},
+	{"1 2 3 cmp→diff 1 2 3 4", "shape\n3\n4", 0}, // shape mismatch is reported first
+	{"D←(2 3⍴⍳6) cmp→diff 3 2⍴⍳6⋄#D", "shape", 0},
+	{"D←(⍳0) cmp→diff 5⋄#D", "shape", 0},
+	{"D←(1;2 3;) cmp→diff (1;2 4;)⋄D[`index]", "2", 0},
+	{"1 cmp→diff 2", "index: \nleft: 1\nright: 2", 0}, // scalars have no index
+	{"D←1 cmp→diff 2⋄⍴D[`index]", "0", 0},
+	{"cmp→diff 2", "fail: diff: cannot be called monadically", 0},
+
 	{"⍝ Left tack, right tack", "apl/primitives/tack.go", 0},
 	{"⊣1 2 3", "1 2 3", 0},      // monadic left: same
 	{"3 2 1⊣1 2 3", "3 2 1", 0}, // dyadic left
@@ -478,6 +716,16 @@ var testCases = []struct {
 	{"2○(○1)÷3", "0.5", small},                              //
 	{"9 11○3.5J¯1.2", "3.5 ¯1.2", small},                    //
 	{"9 11∘.○3.5J¯1.2 2J3 3J4", "3.5 2 3\n¯1.2 3 4", small}, //
+	{"9○3J4", "3", small},                                   // real part
+	{"11○3J4", "4", small},                                  // imaginary part
+	{"10○3J4", "5", small},                                  // magnitude
+	{"|3J4", "5", small},                                    // magnitude
+	{"12○3J4", "0.927295", small},                           // phase
+	{"(10○3J4)×*0J1×12○3J4", "3J4", small},                  // polar form
+	{"+3J4", "3J¯4", small},                                 // conjugate
+	{"¯10○3J4", "3J¯4", small},                              // conjugate
+	{"9 11○5", "5 0", small},                                // real and imaginary part of a real
+	{"12○¯1 0 5", "3.14159 0 0", small},                     // phase of a real
 	{"¯4○¯1", "0", small},                                   //
 	{"3○2", "¯2.18504", small},                              //
 	{"2○1", "0.540302", small},                              //
@@ -633,6 +881,13 @@ var testCases = []struct {
 	{"⌹2 2⍴2 0 0 1", "0.5 0\n0 1", small},
 	// TODO: this fails for big.Float. Remove sfloat and debug
 	{"(1 ¯2 0)⌹3 3⍴3 2 ¯1 2 ¯2 4 ¯1 .5 ¯1", "1\n¯2\n¯2", small},
+	{"X←5 2⍴1 1 1 2 1 3 1 4 1 5 ⋄ Y←3 5 7 9 11 ⋄ Y⌹X", "1\n2", 0},  // exact line fit
+	{"X←4 2⍴1 0 1 1 1 2 1 3 ⋄ Y←1 3 2 5 ⋄ Y⌹X", "1.1\n1.1", small}, // least squares line fit
+	{"X←4 2⍴1 0 1 1 1 2 1 3 ⋄ Y←1 3 2 5 ⋄ B←,Y⌹X ⋄ 1E¯10>⌈/|(⍉X)+.×Y-X+.×B", "1", small},
+	{"X←4 2⍴1 0 1 1 1 2 1 3 ⋄ Y←1 3 2 5 ⋄ B←,Y⌹X ⋄ r←{+/(Y-X+.×⍵)*2} ⋄ (r B)<(r B+0.01 0),(r B-0.01 0),(r B+0 0.01),r B-0 0.01", "1 1 1 1", small},
+	{"X←4 2⍴1 0 1 1 1 2 1 3 ⋄ ⍴⌹X", "2 4", 0}, // left inverse
+	{"X←4 2⍴1 0 1 1 1 2 1 3 ⋄ 1E¯10>⌈/|,(2 2⍴1 0 0 1)-(⌹X)+.×X", "1", small},
+	{"⌹2 3⍴⍳6", "fail: matrix inverse: matrix has more columns than rows", 0},
 	// A←2a30
 	// B←1a10
 	// RHS←A+B**(¯1+⍳6)×○1÷3
@@ -643,6 +898,82 @@ var testCases = []struct {
 	// B←3 3⍴9?100
 	// 0=⌈/⌈/|B-A+.×B⌹A
 
+	{"⍝ Infinity and NaN", "apl/numbers/float.go", 0},
+	{"1÷0", "∞", 0},
+	{"¯1÷0", "¯∞", 0},
+	{"0÷0", "NaN", 0},
+	{"∞ ¯∞ 1", "∞ ¯∞ 1", float},
+	{"*1000", "∞", small},
+	{"⍟0", "¯∞", float},
+	{"⎕NAN", "NaN", 0},
+	{"⎕NAN←1", "fail: cannot assign to a system constant", 0},
+	{"∞+1", "∞", float},
+	{"∞-∞", "NaN", float},
+	{"1÷∞", "0", float},
+	{"(1÷0)=∞", "1", float},
+	{"(1J1÷0)=∞", "1", float},
+	{"∞J1 ¯∞J¯1", "∞J1 ¯∞J¯1", float},
+	{"¯1r2÷0", "¯∞", rational},
+	{"∞>1E308", "1", float},
+	{"¯∞<¯1E308", "1", float},
+	{"⌊/3 ∞ 2", "2", float},
+	{"⎕NAN=⎕NAN", "0", 0},
+	{"⎕NAN≠⎕NAN", "1", 0},
+	{"(⎕NAN<1),(⎕NAN>1),(⎕NAN≤1),(⎕NAN≥1)", "0 0 0 0", float},
+	{"(0÷0)+1", "NaN", float},
+
+	{"⍝ Rational numbers", "apl/big/rat.go", rational},
+	{"3r2", "3r2", rational},
+	{"×¯3r4 0 3r4", "¯1 0 1", rational},
+	{"|¯7r2 3r2", "7r2 3r2", rational},
+	{"⌊3r2 ¯3r2 2", "1 ¯2 2", rational},
+	{"⌈3r2 ¯3r2 2", "2 ¯1 2", rational},
+	{"⌊(10*40)+1r3", "10000000000000000000000000000000000000000", rational},
+	{"⌈(10*40)+1r3", "10000000000000000000000000000000000000001", rational},
+	{"3r2|7r2", "1r2", rational},
+	{"¯3r2|7r2", "¯1", rational},
+	{"3r2|¯7r2", "1", rational},
+	{"0|7r2", "7r2", rational},
+	{"1|(10*40)+1r3", "1r3", rational},
+	{"3r2∨5r6", "1r6", rational},
+	{"2r3^4r9", "4r3", rational},
+	{"¯3r2∨5r6", "1r6", rational},
+	{"¯2r3^4r9", "4r3", rational},
+	{"1r2∨3", "1r2", rational},
+	{"1r2^3", "3", rational},
+	{"0∨3r4", "3r4", rational},
+	{"0^3r4", "0", rational},
+	{"∨/1r2 3r4 5r6", "1r12", rational},
+	{"^/1r2 3r4 5r6", "15r2", rational},
+
+	{"⍝ Exact division, big→set `exact", "apl/big/register.go", 0},
+	{"T←big→set \"exact\"\n1÷3", "1r3", small},
+	{"T←big→set \"exact\"\n6÷3", "2", small},
+	{"T←big→set \"exact\"\n(1÷3)+2÷3", "1", small},
+	{"T←big→set \"exact\"\n(1÷3)×1.5", "0.5", small},
+	{"T←big→set \"exact\"\n1r4+0.25", "0.5", small},
+	{"T←big→set \"exact\"\n0.5+0.25", "0.75", small},
+	{"T←big→set \"exact\"\n(1÷4)*0.5", "0.5", small},
+	{"T←big→set \"exact\"\n⎕PP←5\n⍟1÷3", "¯1.0986", small},
+	{"T←big→set \"exact\"\n2*100", "1267650600228229401496703205376", small},
+	{"T←big→set \"exact\"\n(1÷3)<1÷2", "1", small},
+	{"T←big→set \"exact\"\nT←big→set \"normal\"\n⎕PP←5\n1÷3", "0.33333", small},
+
+	{"⍝ Conversion to rationals", "apl/big/register.go", 0},
+	{"T←big→set \"exact\"\nbig→rat 0.5", "1r2", small},
+	{"T←big→set \"exact\"\nbig→rat ¯0.75", "¯3r4", small},
+	{"T←big→set \"exact\"\nbig→rat 3", "3", small},
+	{"T←big→set \"exact\"\nbig→rat 0.1", "3602879701896397r36028797018963968", small},
+	{"T←big→set \"exact\"\n10 big→rat 0.333333", "1r3", small},
+	{"T←big→set \"exact\"\n10 big→rat ÷3", "1r3", small},
+	{"T←big→set \"exact\"\n100 big→rat ○1", "311r99", small},
+	{"T←big→set \"exact\"\n1000 big→rat ○1", "355r113", small},
+	{"T←big→set \"exact\"\n1000 big→rat ¯1×○1", "¯355r113", small},
+	{"T←big→set \"exact\"\n7 big→rat 0.5", "1r2", small},
+	{"T←big→set \"exact\"\n1 big→rat 2.7", "3", small},
+	{"T←big→set \"exact\"\n(big→rat 0.5)+1r4", "3r4", small},
+	{"T←big→set \"exact\" ⋄ big→rat 1÷0", "fail: rat: number is not finite", small},
+
 	{"⍝ Dates, Times and durations", "apl/numbers/time.go", small},
 	{"2018.12.23", "2018.12.23T00.00.00.000", small},                            // Parse a time
 	{"2018.12.23+12s", "2018.12.23T00.00.12.000", small},                        // Add a duration to a time
@@ -664,6 +995,114 @@ var testCases = []struct {
 	{`"2006-01-02 15:04:05"⍕2019.02.17T15.39.23`, "2019-02-17 15:39:23", small}, // custom date format
 	{`"W"⍕2019.02.27T15.39.23`, "2019w09", small},                               // format calendar week
 	{`"Q"⍕2019.02.27T15.39.23`, "2019Q1", small},                                // format quarter
+	{"⎕NOW>2019.01.01", "1", small},                                             // current time
+	{"0s≤(⎕NOW)-(⎕NOW)", "1", small},                                            // the right argument is evaluated first
+	{"1s>(⎕NOW)-(⎕NOW)", "1", small},                                            // elapsed duration
+	{"⎕NOW←1", "fail: cannot assign to a system constant: ⎕NOW", small},         //
+	{"1+⎕NOW", "fail: ⎕NOW: the numeric tower has no time type", rational},      // big tower
+
+	{"⍝ Format times and durations", "apl/fmt/time.go", small},
+	{"fmt→time 2018.12.23", "2018-12-23 00:00:00", small},
+	{`"Jan 2, 2006 15:04" fmt→time 2018.12.23T13.05`, "Dec 23, 2018 13:05", small},
+	{"⍴fmt→time 2018.12.23+1h×⍳3", "3", small},
+	{"(fmt→time 2018.12.23+1h×⍳3)[3]", "2018-12-23 03:00:00", small},
+	{"fmt→duration 4m×42.195", "2h 48m 46.8s", small},
+	{`"m" fmt→duration 4m×42.195`, "2h 48m", small},
+	{`"h" fmt→duration 4m×42.195`, "2h", small},
+	{`"ms" fmt→duration 4m×42.195`, "2h 48m 46s 800ms", small},
+	{`"s" fmt→duration 3h+5s`, "3h 5s", small},
+	{`"m" fmt→duration 30s`, "0m", small},
+	{"fmt→duration 0s", "0s", small},
+	{"fmt→duration -90s", "¯1m 30s", small},
+	{"fmt→duration 1.5s", "1.5s", small},
+	{`"d" fmt→duration 1h`, "fail: fmt duration: unknown unit: d", small},
+	{"fmt→time 1 2", "fail: fmt time: argument must be a time: apl.Int", small},
+	{"1 fmt→time 2018.12.23", "fail: fmt time: L must be a string: apl.Int", small},
+	{"fmt→duration 2018.12.23", "fail: fmt duration: argument is not a duration", small},
+	{"fmt→time 1h", "fail: fmt time: argument is a duration", small},
+
+	{"⍝ Time zones", "apl/date/zone.go", small},
+	{`"Europe/Berlin" date→convert 2019.07.01T12.00`, "2019.07.01T14.00.00.000 CEST", small}, // daylight saving time
+	{`"Europe/Berlin" date→convert 2019.01.01T12.00`, "2019.01.01T13.00.00.000 CET", small},
+	{`"America/New_York" date→convert 2019.07.01T12.00`, "2019.07.01T08.00.00.000 EDT", small},
+	{`2019.07.01T12.00 = "Europe/Berlin" date→convert 2019.07.01T12.00`, "1", small}, // same instant
+	{`"UTC" date→convert "Europe/Berlin" date→convert 2019.07.01T12.00`, "2019.07.01T12.00.00.000", small},
+	{`"Europe/Berlin" date→attach 2019.07.01T12.00`, "2019.07.01T12.00.00.000 CEST", small},
+	{`("Europe/Berlin" date→attach 2019.07.01T12.00) - 2019.07.01T12.00`, "¯2h0m0s", small},
+	{`"Europe/Berlin" date→attach 2019.03.31T02.30`, "2019.03.31T03.30.00.000 CEST", small}, // wall clock in the DST gap
+	{`("Europe/Berlin" date→attach 2019.03.31T03.00) - "Europe/Berlin" date→attach 2019.03.31T01.00`, "1h0m0s", small},
+	{`"Europe/Berlin" date→attach 2019.10.27T02.30`, "2019.10.27T02.30.00.000 CEST", small}, // wall clock occurs twice
+	{`"UTC" date→convert "Europe/Berlin" date→attach 2019.10.27T02.30`, "2019.10.27T00.30.00.000", small},
+	{`"America/New_York" date→attach 2019.11.03T01.30`, "2019.11.03T01.30.00.000 EDT", small},
+	{`⍴"Europe/Berlin" date→convert 2019.01.01 + 1h×⍳3`, "3", small},
+	{`date→zone "Asia/Tokyo" date→convert 2019.01.01`, "Asia/Tokyo", small},
+	{"date→zone 2019.01.01", "UTC", small},
+	{`"Mars/Olympus" date→convert 2019.01.01`, "fail: convert: unknown time zone Mars/Olympus", small},
+	{`"UTC" date→convert 1h`, "fail: convert: argument is a duration", small},
+
+	{"⍝ Parse durations", "apl/date/duration.go", small},
+	{`date→duration "1h30m"`, "1h30m0s", small},
+	{`date→duration "500ms"`, "500ms", small},
+	{`date→duration "-1h30m"`, "¯1h30m0s", small},
+	{`date→duration "¯1h30m"`, "¯1h30m0s", small},
+	{`date→duration "1h 30m"`, "1h30m0s", small},
+	{`(date→duration "1h30m")=1h+30m`, "1", small},
+	{`2018.12.23 + date→duration "36h"`, "2018.12.24T12.00.00.000", small},
+	{`date→duration "2h" "90s"`, "2h0m0s 1m30s", small},
+	{`(date→duration fmt→duration X)=X←4m×42.195`, "1", small}, // round trip with fmt→duration
+	{`date→duration 2s`, "2s", small},
+	{`date→duration "1 hour"`, "fail: duration: cannot parse", small},
+
+	{"⍝ Calendar arithmetics", "apl/date/add.go", small},
+	{"1 date→addmonths 2019.01.15", "2019.02.15T00.00.00.000", small},
+	{"1 date→addmonths 2019.01.31", "2019.02.28T00.00.00.000", small}, // end of month is clamped
+	{"1 date→addmonths 2020.01.31", "2020.02.29T00.00.00.000", small}, // leap year
+	{"3 date→addmonths 2019.01.31T13.30", "2019.04.30T13.30.00.000", small},
+	{"¯1 date→addmonths 2019.03.31", "2019.02.28T00.00.00.000", small},
+	{"12 date→addmonths 2019.12.31", "2020.12.31T00.00.00.000", small},
+	{"13 date→addmonths 2019.12.15", "2021.01.15T00.00.00.000", small},
+	{"1 date→addyears 2020.02.29", "2021.02.28T00.00.00.000", small},
+	{"4 date→addyears 2020.02.29", "2024.02.29T00.00.00.000", small},
+	{"¯1 date→addyears 2019.06.30", "2018.06.30T00.00.00.000", small},
+	{"⍴1 date→addmonths 2019.01.31 2019.02.28", "2", small},
+	{"(1 date→addmonths 2019.01.31 2019.02.28)[2]", "2019.03.28T00.00.00.000", small},
+	{"1.5 date→addmonths 2019.01.31", "fail: addmonths: L must be an integer", small},
+
+	{"⍝ Working days", "apl/date/workday.go", small},
+	{"date→weekend 2019.01.04 2019.01.05 2019.01.06 2019.01.07", "0 1 1 0", small}, // Fri Sat Sun Mon
+	{"5 6 date→weekend 2019.01.04 2019.01.05 2019.01.06", "1 1 0", small},
+	{"7 date→weekend 2019.01.05 2019.01.06", "0 1", small},
+	{"1 date→addworkdays 2019.01.04", "2019.01.07T00.00.00.000", small}, // Friday to Monday
+	{"2 date→addworkdays 2019.01.04T09.30", "2019.01.08T09.30.00.000", small},
+	{"5 date→addworkdays 2019.01.07", "2019.01.14T00.00.00.000", small},
+	{"¯1 date→addworkdays 2019.01.07", "2019.01.04T00.00.00.000", small},
+	{"1 date→addworkdays 2019.01.05", "2019.01.07T00.00.00.000", small}, // start on a Saturday
+	{"0 date→addworkdays 2019.01.05", "2019.01.05T00.00.00.000", small},
+	{"(2;5 6;) date→addworkdays 2019.01.03", "2019.01.07T00.00.00.000", small}, // Friday and Saturday off
+	{"10 date→addworkdays 2019.01.05", "2019.01.18T00.00.00.000", small},
+	{"¯10 date→addworkdays 2019.01.05", "2018.12.24T00.00.00.000", small},
+	{"(12;7 7;) date→addworkdays 2019.01.06", "2019.01.19T00.00.00.000", small},
+	{"2610 date→addworkdays 2019.01.07", "2029.01.08T00.00.00.000", small},
+	{"⍴3 date→addworkdays 2019.01.04 2019.01.05", "2", small},
+	{"1 8 date→weekend 2019.01.05", "fail: weekend: weekdays must be numbers from 1 (Monday) to 7 (Sunday)", small},
+	{"(1;⍳7;) date→addworkdays 2019.01.05", "fail: addworkdays: every day is a weekend day", small},
+
+	{"⍝ Unix time", "apl/date/unix.go", small},
+	{"date→tounix 2019.01.01", "1546300800", small},
+	{"date→tounix 1970.01.01", "0", small},
+	{"date→fromunix 1546300800", "2019.01.01T00.00.00.000", small},
+	{"(date→fromunix date→tounix X)=X←2018.12.23T13.05.07", "1", small},
+	{`"ms" date→tounix 2019.01.01T00.00.01.5`, "1546300801500", small},
+	{`"ms" date→fromunix 1546300801500`, "2019.01.01T00.00.01.500", small},
+	{`(X - "ms" date→fromunix "ms" date→tounix X)=0s⊣X←2019.01.01T00.00.01.25`, "1", small},
+	{"date→fromunix 1546300801.5", "2019.01.01T00.00.01.500", small},
+	{"date→fromunix ¯86400", "1969.12.31T00.00.00.000", small},
+	{"date→fromunix 1E20", "fail: fromunix: argument is out of range", small},
+	{"date→fromunix 10000000000", "fail: fromunix: argument is out of range", small},
+	{`"ms" date→fromunix 9000000000000`, "2255.03.14T16.00.00.000", small},
+	{"date→tounix 1970.01.01 1970.01.02", "0 86400", small},
+	{`date→tounix "Europe/Berlin" date→attach 1970.01.01T01.00`, "0", small},
+	{`"us" date→tounix 2019.01.01`, "fail: tounix: L must be \"s\" or \"ms\"", small},
 
 	{"⍝ Round times and durations", "apl/numbers/time.go", small},
 	{"`Y ⌊2019.02.27T13.39.02", "2019.01.01T00.00.00.000", small},
@@ -685,6 +1124,20 @@ var testCases = []struct {
 	// {`S←0.0 n→f "%.0f"⋄ +.×.*/2 3 4`, "2417851639229258349412352", 0},
 	{`+.×.*/2 3 4`, "2.41785E+24", small},
 	{`+.*.×/2 3 4`, "24", 0},
+	{`+.×\2 3 4`, "2 6 24", 0},
+	{`-.×/2 3 4`, "24", 0},
+	{`-.÷/2 3 4`, "2.66667", small}, // right to left: 2÷(3÷4)
+	{`-⍨/1 2 3`, "0", 0},            // 1-⍨(2-⍨3)
+	{`-⍨\1 2 3`, "1 1 0", 0},        // prefix reductions, not a running fold
+	{`-∘-/1 2 3`, "6", 0},           // L-(-R)
+	{`+∘×/2 3 4`, "3", 0},           // 2+×(3+×4)
+	{`+∘×\2 3 4`, "2 3 3", 0},
+	{`+⍣2/1 2 3`, "9", 0}, // 1+1+(2+2+3)
+	{`-⍨⍀3 2⍴⍳6`, "1 2\n2 2\n1 0", 0},
+	{`+.×\[1]3 2⍴⍳6`, "1 2\n3 8\n15 48", 0},
+	{`¯2 -⍨/1 2 3 4`, "¯1 ¯1 ¯1", 0},
+	{`f←+.× ⋄ f/2 3 4`, "24", 0},
+	{`A←2 2⍴1 2 3 4 ⋄ B←2 2⍴0 1 1 0 ⋄ +.×/(A;B;)`, "2 1\n4 3", 0},
 
 	{"⍝ Identify item for reduction over empty array", "apl/operators/identity.go", 0},
 	{"+/⍳0", "0", 0},
@@ -707,6 +1160,14 @@ var testCases = []struct {
 	{"≠/⍳0", "0", 0},
 	{"⊤/⍳0", "0", 0},
 	{"⌽/⍳0", "0", 0},
+	{"-⍨/⍳0", "0", 0},
+	{"÷⍨/⍳0", "1", 0},
+	{"+.×/⍳0", "1", 0},
+	{"∘.×/⍳0", "1", 0},
+	{"×.+/⍳0", "0", 0},
+	{"+.×/2 0⍴0", "1 1", 0},
+	{"0 -⍨/⍳3", "0 0 0 0", 0},
+	{"+∘×/⍳0", "fail: no identity item", 0},
 	{"⊖/⍳0", "0", 0},
 	{"∨/0 3⍴ 1", "", 0},
 	{"∨/3 3⍴ ⍳0", "0 0 0", 0},
@@ -729,6 +1190,14 @@ var testCases = []struct {
 	{"1 2 3+¨4 5 6", "5 7 9", 0}, // dyadic each
 	{"1+¨1", "2", 0},             // dyadic each
 
+	{"⍝ Generate", "apl/operators/generate.go", 0},
+	{"⎕IO←0 ⋄ {⍵*2}⌾4", "0 1 4 9", 0},
+	{"{(⍵-1)*2}⌾4", "0 1 4 9", 0},
+	{"{+/⍵}⌾2 3", "2 3 4\n3 4 5", 0},
+	{"10{⍺+⍵}⌾3", "11 12 13", 0},
+	{"⍴{⍵}⌾0", "0", 0},
+	{"{⍵ ⍵}⌾3", "fail: generate: result must be a scalar", 0},
+
 	{"⍝ Commute, duplicate", "apl/operators/commute.go", 0},
 	{"∘.≤⍨1 2 3", "1 1 1\n0 1 1\n0 0 1", 0},
 	{"+/∘(÷∘⍴⍨)⍳10", "5.5", small}, // mean value
@@ -742,12 +1211,32 @@ var testCases = []struct {
 	{"1∘○ 10 20 30", "¯0.544021 0.912945 ¯0.988032", small},
 	{"+∘÷/40⍴1", "1.61803", small},     // Form IV, golden ratio (continuous-fraction)
 	{"(*∘0.5)4 16 25", "2 4 5", float}, // Form III
+	{"(2∘-)5", "¯3", 0},                // Form II, left bind
+	{"(-∘2)5", "3", 0},                 // Form III, right bind
+	{"(2∘-)1 2 3", "1 0 ¯1", 0},        // Form II
+	{"(-∘2)1 2 3", "¯1 0 1", 0},        // Form III
+	{"(10∘÷)2", "5", 0},                // Form II
+	{"(÷∘10)20", "2", 0},               // Form III
+	{"f←2∘⍴ ⋄ g←⍴∘2 ⋄ (f 3),g 3", "3 3 2 2 2", 0},
+	{"1(2∘-)5", "fail: compose: function with bound argument cannot be called dyadically", 0},
+	{"1(-∘2)5", "fail: compose: function with bound argument cannot be called dyadically", 0},
 
 	{"⍝ Power operator", "apl/operators/power.go", 0},
 	{"⍟⍣2 +2 3 4", "¯0.366513 0.0940478 0.326634", float}, // log log
 	// TODO: 1+∘÷⍣=1 oscillates for big.Float.
 	// TODO: Add comparison tolerance and remove sfloat.
 	{"1+∘÷⍣=1", "1.61803", small}, // fixed point iteration golden ratio
+	{"1+∘÷⍣(=;100;)1", "1.61803", small},
+	{"1+∘÷⍣(=;5;)1", "fail: power: iteration limit exceeded: 5", 0},
+	{"{⍵+1}⍣(=;10;)0", "fail: power: iteration limit exceeded: 10", 0},
+	{"{⍵+1}⍣=0", "fail: power: iteration limit exceeded: 1000", 0},
+	{"{⍵+1}⍣{⍺=5}0", "5", 0},
+	{"{1-⍵}⍣=0", "fail: power: cycle detected: period 2", 0},
+	{"{3|⍵+1}⍣≡1", "fail: power: cycle detected: period 3", 0},
+	{"{3|⍵+1}⍣{⍵=7}1", "fail: power: iteration limit exceeded: 1000", 0},
+	{"{?6}⍣{⍺=6}1", "6", 0},
+	{"{3|⍵+1}⍣{0=⍺}1", "0", 0},
+	{"{⍵+1}⍣(=;0;)0", "fail: power: iteration limit must be a positive integer", 0},
 	{"⍝ TODO: function inverse", "", 0},
 
 	{"⍝ Rank operator", "apl/operators/rank.go", 0},
@@ -790,6 +1279,12 @@ var testCases = []struct {
 	{"A←2 2⍴⍳4 ⋄ +A[1;1]←3 ⋄ A", "3\n3 2\n3 4", 0},
 	{"A←⍳5 ⋄ A[2 3]←10 ⋄ A", "1 10 10 4 5", 0},
 	{"A←2 3⍴⍳6 ⋄ A[;2 3]←2 2⍴⍳4 ⋄ A", "1 1 2\n4 3 4", 0},
+	{"A←1 5 3 ⋄ A[1 1]←7 8 ⋄ A", "8 5 3", 0},              // repeated index: the last value is stored
+	{"A←1 5 3 2 ⋄ A[1 2 4]{⍺⌈⍵}←4 4 4 ⋄ A", "4 5 3 4", 0}, // indexed modification
+	{"A←1 5 3 ⋄ A[1 3]⌈←2 ⋄ A", "2 5 3", 0},
+	{"A←2 2⍴⍳4 ⋄ A[2;]{⍺+⍵}←10 ⋄ A", "1 2\n13 14", 0},
+	{"A←0 0 0 ⋄ A[1 1 2]+←1 2 3 ⋄ A", "3 3 0", 0}, // repeated index: applied cumulatively
+	{"A←0 0 0 ⋄ A[2 2 2]{⍺⌈⍵}←5 1 3 ⋄ A", "0 5 0", 0},
 	{"⍝ TODO: choose/reach indexed assignment", "", 0},
 
 	{"⍝ Multiple assignment", "apl/operators/assign.go", 0},
@@ -799,12 +1294,31 @@ var testCases = []struct {
 	{"(A B C)←2 3 4 ⋄ A ⋄ B ⋄ C ", "2\n3\n4", 0},
 	{"-A B C←1 2 3 ⋄ A B C", "¯1 ¯2 ¯3\n1 2 3", 0},
 
+	{"⍝ Nested list assignment", "apl/operators/assign.go", 0},
+	{"(A;B;)←(1 2;3;) ⋄ A ⋄ B", "1 2\n3", 0},
+	{"((A B);C;)←(1 2;3;) ⋄ A ⋄ B ⋄ C", "1\n2\n3", 0},
+	{"(A;(B C);D;)←(1 2 3;4 5;6;) ⋄ A ⋄ B ⋄ C ⋄ D", "1 2 3\n4\n5\n6", 0},
+	{"((A;B;);C;)←((1;2 3;);4;) ⋄ A ⋄ B ⋄ C", "1\n2 3\n4", 0},
+	{"((A B);(C;(D E););)←(1 2;(3;4 5;);) ⋄ A B C D E", "1 2 3 4 5", 0},
+	{"((A B);C;)←5 ⋄ A B C", "5 5 5", 0},
+	{"(A;B;)←(1;2;) ⋄ (A;B;)+←(10;20;) ⋄ A B", "11 22", 0},
+	{"(A;B;)←(1;2;3;)", "fail: list assignment: 2 targets but 3 values", 0},
+	{"((A B);C;)←(1 2 3;4;)", "fail: vector assignment is non-conformant", 0},
+
 	{"⍝ Modified assignment", "apl/operators/assign.go", 0},
 	{"A←1 ⋄ A+←1 ⋄ A", "2", 0},
 	{"A←1 2⋄ A+←1 ⋄ A", "2 3", 0},
 	{"A←1 2 ⋄ A+←3 4 ⋄ A", "4 6", 0},
 	{"A←1 2 ⋄ A{⍺+⍵}←3 ⋄ A", "4 5", 0},
 	{"A B C←1 2 3 ⋄ A B C +← 4 5 6 ⋄ A B C", "5 7 9", 0},
+	{"A←2 3⍴⍳6 ⋄ A+[2]←1 2 3 ⋄ A", "2 4 6\n5 7 9", 0}, // modified assignment with axis
+	{"A←2 3⍴⍳6 ⋄ A+←[2]1 2 3 ⋄ A", "2 4 6\n5 7 9", 0}, // axis may follow the arrow
+	{"A←2 3⍴⍳6 ⋄ A×←[1]10 100 ⋄ A", "10 20 30\n400 500 600", 0},
+	{"A←2 3⍴⍳6 ⋄ A{⍺+[2]⍵}←1 2 3 ⋄ A", "2 4 6\n5 7 9", 0}, // lambda taking a vector
+	{"A←1 2 3 ⋄ A{⍺,⌽⍵}←4 5 ⋄ A", "1 2 3 5 4", 0},
+	{"A←1 2 3 ⋄ A(⊢-⊣)←1 ⋄ A", "0 ¯1 ¯2", 0}, // train as a modifier
+	{"A←1 2 3 ⋄ A(+∘×)←2 ⋄ A", "2 3 4", 0},
+	{"A←1 2 3 ⋄ A←[1]4", "fail: assignment with axis requires a modifying function", 0},
 
 	// Selective specification APL2 p.41, DyaRef p.21
 	{"⍝ Selective assignment/specification", "apl/operators/assign.go", 0},
@@ -866,6 +1380,12 @@ var testCases = []struct {
 	{"{A←1⋄{A←⍵}⍵+1}1", "2", 0},
 	{"A←1⋄S←{A←2}0⋄A", "1", 0},
 	{"A←1⋄S←{A⊢←2}0⋄A", "2", 0}, // overwrite a global
+	{"A←1⋄{A⊢←2⋄A}0⋄A", "2\n2", 0},
+	{"A←1⋄{{A⊢←3}0}0⋄A", "3\n3", 0},                 // write through two levels
+	{"A←1⋄{A←5⋄X←{A⊢←3}0⋄A}0⋄A", "3\n1", 0},         // the nearest enclosing A is overwritten
+	{"A←1⋄{A←5⋄A⊢←3⋄A}0⋄A", "3\n1", 0},              // a local A shadows the global
+	{"A←1⋄{A⊣←2⋄A}0⋄A", "1\n1", 0},                  // left tack keeps the value
+	{"{B⊢←2}0", "fail: variable does not exist", 0}, // no variable in any scope
 	{"A←1⍴1⋄S←{A[1]←2}0⋄A", "2", 0},
 	{"A←1⋄{A+←1⋄A}0⋄A", "2\n2", 0},
 	{"+X←{A←3⋄B←4}0", "4", 0},
@@ -898,12 +1418,198 @@ var testCases = []struct {
 	{"(⌊÷+×-)4", "¯0.25", float},
 	{"6(⌊÷+×-)4", "0.2", float},
 	{"(3+*)4", "57.5982", float}, // Agh fork
-	//{"(⍳(/∘⊢)⍳)3", "1 2 2 3 3 3", 0}, // The hybrid token does not parse.
+	{"(⍳(/∘⊢)⍳)3", "1 2 2 3 3 3", 0},
+	{"1 2 3(/∘⊢)4 5 6", "4 5 5 6 6 6", 0},
+	{"f←(/∘⊢) ⋄ 1 2 f 3 4", "3 4 4", 0},
+	{"1 0 1(/)4 5 6", "4 6", 0},
+	{"2(⌿)2 2⍴⍳4", "1 2\n1 2\n3 4\n3 4", 0},
+	{"1 0 1(\\)1 2", "1 0 2", 0},
+	{"1 0 1(⍀)2 2⍴⍳4", "1 2\n0 0\n3 4", 0},
+	{"(⍳(⌿∘⊢)⍳)2", "1 2 2", 0},
+	{"(/)1 2", "fail: function / requires a left argument", 0},
+
+	{"⍝ Package variables", "", 0},
+	{"mypkg→X←3 ⋄ mypkg→X", "3", 0},
+	{"mypkg→X←⍳3 ⋄ mypkg→X←mypkg→X×2 ⋄ mypkg→X", "2 4 6", 0},
+	{"mypkg→f←{⍵+1} ⋄ mypkg→f 1 2", "2 3", 0},
+	{"mypkg→X←3 ⋄ X", "X", 0},
+	{"mypkg→f←1", "fail: cannot assign a value (apl.Int) to mypkg→f: it is a function name (lowercase), use mypkg→F instead", 0},
+	{"s→LOWER←'abc'", "fail: cannot assign to s→LOWER: package s is read-only", 0},
+
+	{"⍝ Namespaces", "apl/namespace.go", 0},
+	{"N←a→n 0 ⋄ N→X←1 2 3 ⋄ N→X", "1 2 3", 0},
+	{"N←a→n 0 ⋄ N→f←{⍵+1} ⋄ N→f 1 2", "2 3", 0},
+	{"N←a→n 0 ⋄ N→X←3 ⋄ N→f←{⍵×N→X} ⋄ N→f 2", "6", 0},
+	{"N←a→n `X`Y#1 2 ⋄ N→X+N→Y", "3", 0},
+	{"N←a→n 0 ⋄ N→Y←2 ⋄ N→X←1 ⋄ #N", "X Y", 0},
+	{"N←a→n 0 ⋄ N→X←1 ⋄ N[`X]", "1", 0},
+	{"N←a→n 0 ⋄ N→X←1 ⋄ N[`X]←5 ⋄ N→X", "5", 0},
+	{"N←a→n 0 ⋄ M←N ⋄ M→X←1 ⋄ N→X", "1", 0},
+	{"N←a→n 0 ⋄ N→X←1 ⋄ N→f←+ ⋄ N", "X: 1\nf: +", 0},
+	{"N←a→n 0 ⋄ N→X←1 ⋄ X", "X", 0},
+	{"N←a→n 0 ⋄ N→f←1", "fail: cannot assign a value (apl.Int) to N→f: it is a function name (lowercase), use N→F instead", 0},
+	{"N←a→n `f#1", "fail: a n: cannot assign a value (apl.Int) to f: it is a function name (lowercase), use F instead", 0},
+	{"N←1 ⋄ N→X←1", "fail: cannot assign to N→X: N is not a namespace", 0},
+	{"N←a→n `X#0 ⋄ N→N←N ⋄ N", "N: (cycle)\nX: 0", 0},
+	{"N←a→n `X#0 ⋄ N→L←(N;1;) ⋄ N", "L: ((cycle);1;)\nX: 0", 0},
+
+	{"⍝ Deep copy", "apl/a/copy.go", 0},
+	{"L←(1;(2;(3;4;););)⋄M←a→d L⋄M[2;2;1]←9⋄L,M", "(1;(2;(3;4;););1;(2;(9;4;););)", 0},
+	{"D←`a`b#(1 2;`c#3;)⋄E←a→d D⋄E[`b;`c]←5⋄D[`b;`c],E[`b;`c]", "3 5", 0},
+	{"D←`a`b#(1 2;`c#3;)⋄E←a→d D⋄E[`a]←5⋄D[`a]", "1 2", 0},
+	{"T←⍉`a`b#(1 2;3 4;)⋄U←a→d T⋄U[1;`a]←5⋄T[`a]", "1 2", small},
+	{"N←a→n `X#1⋄D←`n#N⋄E←D⋄F←E[`n]⋄F→X←2⋄N→X", "2", 0},
+	{"N←a→n `X#1⋄D←`n#N⋄E←a→d D⋄F←E[`n]⋄F→X←2⋄N→X", "1", 0},
+	{"N←a→n `X#1⋄L←(N;2;)⋄M←a→d L⋄F←M[1]⋄F→X←3⋄N→X,F→X", "1 3", 0},
+	{"N←a→n `X#1⋄M←a→d N⋄M→X←2⋄N→X,M→X", "1 2", 0},
+	{"N←a→n `X#1⋄N→Y←N⋄M←a→d N⋄F←M→Y⋄F→X←2⋄N→X,M→X", "1 2", 0},
+	{"X←go→t 0⋄Y←X⋄Y[`I]←3⋄X[`I]", "3", 0},
+	{"X←go→t 0⋄X[`V]←`a`b⋄Y←a→d X⋄Y[`V]←`c`d⋄X[`V]", "a b", 0},
+	{"X←go→t 0⋄X[`S;`A]←1⋄Y←a→d X⋄Y[`S;`A]←2⋄X[`S;`A],Y[`S;`A]", "1 2", 0},
+
+	{"⍝ Frozen values", "apl/frozen.go", 0},
+	{"X←a→f 1 2 3⋄X", "1 2 3", 0},
+	{"X←a→f 1 2 3⋄X+1", "2 3 4", 0},
+	{"X←a→f 1 2 3⋄X[2]", "2", 0},
+	{"X←a→f 1 2 3⋄(A B C)←X⋄B", "2", 0},
+	{"X←a→f 1 2 3⋄X[2]←5", "fail: assign X: value is frozen", 0},
+	{"X←a→f 1 2 3⋄X+←1", "fail: assign X: value is frozen", 0},
+	{"X←a→f 1 2 3⋄(1↑X)←5", "fail: assign X: value is frozen", 0},
+	{"X←a→f 1 2 3⋄{X⊢←⍵}5", "fail: assign X: value is frozen", 0},
+	{"X←a→f 1 2 3⋄X←5⋄X", "5", 0},
+	{"X←a→f 1 2 3⋄Y←X⋄Y[1]←5⋄X,Y", "1 2 3 5 2 3", 0},
+	{"D←a→f `a`b#1 2⋄D[`b]", "2", 0},
+	{"D←a→f `a`b#1 2⋄D[`a]←3", "fail: assign D: value is frozen", 0},
+	{"D←`a`b#(1;a→f 2 3;)⋄D[`b;1]", "2", 0},
+	{"D←`a`b#(1;a→f 2 3;)⋄D[`b;1]←5", "fail: assign D: assign obj-depth: value is frozen", 0},
+	{"L←(1;a→f (2;3;);)⋄L[2;1]", "2", 0},
+	{"L←(1;a→f (2;3;);)⋄L[2;1]←5", "fail: assign L: list element is frozen", 0},
+	{"L←(1;2;)⋄L[2]←a→f (3;4;)⋄L[2;1]←5", "fail: assign L: list element is frozen", 0},
+	{"≢¨(1;a→f 2 3;)", "(1;2;)", 0},
+	{"L←(1;a→f 2 3;)⋄+/¨L", "(1;5;)", 0},
+	{"N←a→f a→n `X#1⋄N→X", "1", 0},
+	{"N←a→f a→n `X#1⋄N→X←2", "fail: cannot assign to N→X: N is frozen", 0},
+	{"N←a→f a→n `X#1⋄F←N⋄F→X←2⋄N→X,F→X", "1 2", 0},
+	{"N←a→n `X#1⋄L←a→f (N;2;)⋄M←L⋄F←M[1]⋄F→X←2⋄N→X", "1", 0},
+	{"N←a→n `X#1⋄L←a→f (N;2;)⋄F←L[1]⋄F→X←2⋄G←L[1]⋄G→X,F→X", "1 2", 0},
+	{"X←a→f go→t 0⋄Y←X⋄Y[`I]←3⋄X[`I],Y[`I]", "0 3", 0},
+
+	{"⍝ Describe packages and objects", "apl/a/describe.go", 0},
+	{"D←a→i \"s\" ⋄ (D[`name]=`toupper)/D", "name: toupper\nkind: function\ntype: func(string) string", 0},
+	{"D←a→i \"s\" ⋄ (D[`kind]=`value)/D[`name]", "ALNUM LOWER", 0},
+	{"D←a→i \"go\" ⋄ (D[`name]=`t)/D[`type]", "func() *xgo.T", 0},
+	{"a→i go→s 0", "name kind type\nA value int\nB value int\nV value []int\nsum function func() int", 0},
+	{"N←a→n `X`f#(1;+;) ⋄ (a→i N)[`name`kind]", "name kind\nX value\nf function", 0},
+	{"a→i \"nopkg\"", "fail: a i: package nopkg is not registered", 0},
+	{"a→i 1", "fail: a i: argument must be a package name or an object: apl.Int", 0},
 
 	{"⍝ Go interface package strings", "apl/strings/register.go", 0},
 	{`u←s→toupper ⋄ u "alpha"`, "ALPHA", 0},
 	{`";" s→join "alpha" "beta" `, "alpha;beta", 0},
 
+	{"⍝ Symbols and rune vectors", "apl/strings/symbol.go", 0},
+	{"s→name`alpha", "a l p h a", 0},
+	{"(s→name`alpha)≡'alpha'", "1", 0},
+	{"s→symbol'beta'", "beta", 0},
+	{"(s→symbol'beta')≡`beta", "1", 0},
+	{"s→name`alpha`beta", "(a l p h a;b e t a;)", 0},
+	{"s→symbol('alpha';'beta';)", "alpha beta", 0},
+	{"(s→symbol s→name`alpha)≡`alpha", "1", 0},
+	{"(s→name s→symbol'beta')≡'beta'", "1", 0},
+	{"(s→symbol s→name`a`bc`def)≡`a`bc`def", "1", 0},
+	{`"Hello" s→equalfold "hELLO"`, "1", 0},
+	{`"Hello" s→equalfold "World"`, "0", 0},
+	{`"ΣΑΣ" s→equalfold "σας"`, "1", 0},
+	{`"Hello" ≡ "hELLO"`, "0", 0},
+	{`s→tolower "ÄÖÜ HELLO"`, "äöü hello", 0},
+	{`s→title "the quick brown fox jumps over the lazy dog"`, "The Quick Brown Fox Jumps Over The Lazy Dog", 0},
+	{`s→title "ça va être"`, "Ça Va Être", 0},
+	{`s→title s→tolower "hELLO wORLD"`, "Hello World", 0},
+	{`s→equalfold "a"`, "fail: function EqualFold requires 2 arguments", 0},
+	{`3 s→repeat "ab"`, "ababab", 0},
+	{`s→repeat "ab" 3`, "ababab", 0},
+	{`⍴0 s→repeat "ab"`, "", 0},
+	{`"ab" s→repeat 3`, "fail: function Repeat argument 1: expected string", 0},
+	{`"e" s→count "cheese"`, "3", 0},
+	{`s→count "cheese" "e"`, "3", 0},
+	{`"aa" s→count "aaaa"`, "2", 0}, // non-overlapping
+	{`"x" s→count "cheese"`, "0", 0},
+	{`⌽'DESSERTS'`, "S T R E S S E D", 0},
+	{`s→symbol⌽s→name "DESSERTS"`, "STRESSED", 0},
+	{`s→symbol⌽s→name "äöü"`, "üöä", 0},
+	{"(`name#\"Bob\") s→template \"Hi {name}\"", "Hi Bob", 0},
+	{"(`name`n#(\"Bob\";3;)) s→template \"{name} has {n} new messages, {name}\"", "Bob has 3 new messages, Bob", 0},
+	{"(`x#1 2) s→template \"x={x}\"", "x=1 2", 0},
+	{"(`x#1) s→template \"{{x}} is {x}\"", "{x} is 1", 0}, // escaped braces
+	{"(`x#1) s→template \"no placeholders\"", "no placeholders", 0},
+	{"(`x#1) s→template \"{y}\"", "fail: template: missing key: y", 0},
+	{"(`x#1) s→template \"{x\"", "fail: template: unmatched {", 0},
+	{"(`x#1) s→template \"x}\"", "fail: template: unmatched }", 0},
+	{`s→bytes "abc"`, "97 98 99", 0},
+	{`s→bytes "süß"`, "115 195 188 195 159", 0},
+	{`≢'süß'`, "3", 0},
+	{`≢s→bytes "süß"`, "5", 0},
+	{`≢s→bytes 'süß'`, "5", 0},
+	{`(s→name "süß")[2]`, "ü", 0},
+	{`s→frombytes 115 195 188 195 159`, "süß", 0},
+	{`(s→frombytes s→bytes "日本")≡"日本"`, "1", 0},
+	{`s→frombytes 65`, "A", 0},
+	{`⍴s→bytes ""`, "0", 0},
+	{`s→frombytes 256`, "fail: frombytes: value is not a byte: 256", 0},
+	{`⍴s→lines "a\nbc\nd"`, "3", 0},
+	{`(s→lines "a\nbc\nd")[2]`, "bc", 0},
+	{`s→lines "a\r\nbc\r\nd"`, "a bc d", 0},
+	{`"bc"≡(s→lines "a\r\nbc\r\nd")[2]`, "1", 0},
+	{`(s→lines "a\r\nbc")≡s→lines "a\nbc"`, "1", 0},
+	{`⍴s→lines "a\nb\n"`, "2", 0},
+	{`⍴1 s→lines "a\nb\n"`, "3", 0},
+	{`⍴0 s→lines "a\r\nb\r\n"`, "2", 0},
+	{`⍴s→lines "a\n\nb"`, "3", 0},
+	{`⍴s→lines ""`, "1", 0},
+	{`2 s→lines "a"`, "fail: lines: L must be 0 or 1", 0},
+	{`8 "." s→padleft "abc"`, ".....abc", 0},
+	{`8 "." s→padright "abc"`, "abc.....", 0},
+	{`8 "." s→center "abc"`, "..abc...", 0},
+	{`8 "." s→center "ab"`, "...ab...", 0},
+	{`⍴8 s→padleft "abc"`, "", 0},
+	{`⍪6 "." s→padright "a" "bbb" "cc"`, "a.....\nbbb...\ncc....", 0},
+	{`⍪6 "." s→padleft "a" "bbb" "toolong"`, ".....a\n...bbb\ntoolong", 0}, // longer strings are unchanged
+	{`5 "ä" s→padright "ü"`, "üääää", 0},
+	{`8 "ab" s→padleft "x"`, "fail: padleft: fill must be a single character", 0},
+	{"s→LOWER≡'abcdefghijklmnopqrstuvwxyz'", "1", 0},
+	{"s→ALNUM≡⎕A,s→LOWER,⎕D", "1", 0},
+	{"⍴s→ALNUM", "62", 0},
+	{"+/'a1B_c'∊s→ALNUM", "4", 0},
+
+	{"⍝ Base64", "apl/b64/register.go", 0},
+	{"b64→encode 'alpha'", "YWxwaGE=", 0},
+	{"b64→encode \"alpha\"", "YWxwaGE=", 0},
+	{"⍴⍴b64→encode 'alpha'", "0", 0},
+	{"b64→decode 'YWxwaGE='", "97 108 112 104 97", 0},
+	{"b64→encode 97 108 112 104 97", "YWxwaGE=", 0},
+	{"s→frombytes b64→decode 'YWxwaGE='", "alpha", 0},
+	{"(s→frombytes b64→decode b64→encode X)≡X←\"alpha beta\"", "1", 0},
+	{"(s→frombytes b64→decode b64→encode X)≡X←s→symbol ⎕AV", "1", 0},
+	{"⎕AV≡s→name s→frombytes b64→decode b64→encode ⎕AV", "1", 0},
+	{"b64→decode b64→encode 0 255 128 10", "0 255 128 10", 0}, // binary data
+	{"b64→decode ''", "", 0},
+	{"b64→encode 1 256", "fail: b64 encode: value is not a byte: 256", 0},
+	{"b64→encode ⎕AV[252+⍳4]", "w7zDvcO+w78=", 0},
+	{"\"url\" b64→encode ⎕AV[252+⍳4]", "w7zDvcO-w78=", 0},
+	{"b64→encode '>>>???'", "Pj4+Pz8/", 0},
+	{"\"url\" b64→encode '>>>???'", "Pj4-Pz8_", 0},
+	{"s→frombytes \"url\" b64→decode \"url\" b64→encode '>>>???'", ">>>???", 0},
+	{"b64→decode 'Pj4-Pz8_'", "fail: b64 decode: illegal base64 data at input byte 3", 0},
+	{"`hex b64→encode 'alpha'", "fail: b64 encode: unknown alphabet: hex (use std or url)", 0},
+
+	{"⍝ Hash", "apl/hash/register.go", 0},
+	{"hash→md5 'abc'", "900150983cd24fb0d6963f7d28e17f72", 0},
+	{"hash→md5 \"\"", "d41d8cd98f00b204e9800998ecf8427e", 0},
+	{"hash→sha256 'abc'", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", 0},
+	{"hash→sha256 \"The quick brown fox jumps over the lazy dog\"", "d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592", 0},
+	{"⍴⍴hash→sha256 'abc'", "0", 0},
+	{"'abc' hash→md5 'abc'", "fail: hash md5 must be called monadically", 0},
+
 	{"⍝ Lists", "apl/list.go", 0},
 	{"(1;2;)", "(1;2;)", 0},
 	{"(1 5 9;(2;3+4;);)", "(1 5 9;(2;7;);)", 0},
@@ -914,6 +1620,139 @@ var testCases = []struct {
 	{"+/(1;2;3;)", "6", 0},
 	{"+/(1;2;(3;4;);)", "6 7", 0},
 
+	{"⍝ Zip", "apl/list/zip.go", 0},
+	{"1 2 3 list→zip 4 5 6", "(1 4;2 5;3 6;)", 0},
+	{"⍴1 2 3 list→zip 4 5 6", "3", 0},
+	{"≢¨1 2 3 list→zip 4 5 6", "(2;2;2;)", 0},
+	{"Z←1 2 3 list→zip 4 5 6⋄Z[2]", "2 5", 0},
+	{"1 2 3 list→zip 4 5", "(1 4;2 5;)", 0},            // truncate to the shorter length
+	{"`a`b`c list→zip (1 2;3;)", "((a;1 2;);b 3;)", 0}, // pair with an array is a list
+	{"1 2 list→zip ⍳0", "()", 0},
+	{"(2 2⍴⍳4) list→zip 1 2", "fail: zip: left argument: must be a vector", 0},
+
+	{"⍝ Flatten to depth", "apl/list/flat.go", 0},
+	{"list→flat (1;(2;(3;4;););5;)", "(1;2;(3;4;);5;)", 0},
+	{"1 list→flat (1;(2;(3;4;););5;)", "(1;2;(3;4;);5;)", 0},
+	{"2 list→flat (1;(2;(3;4;););5;)", "(1;2;3;4;5;)", 0},
+	{"9 list→flat (1;(2;(3;4;););5;)", "(1;2;3;4;5;)", 0},
+	{"0 list→flat (1;(2;(3;4;););5;)", "(1;(2;(3;4;););5;)", 0},
+	{"(9 list→flat L)≡∊L←(1;(2;(3;4 5;););6;)", "1", 0},
+	{"list→flat 1 2 3", "1 2 3", 0},
+	{"¯1 list→flat (1;2;)", "fail: flat: L must be a non-negative integer", 0},
+
+	{"⍝ Cartesian product", "apl/list/product.go", 0},
+	{"⍴list→product (1 2;3 4 5;6 7;)", "2 3 2", 0},
+	{"P←list→product (1 2;3 4 5;6 7;)⋄P[1;1;1]⋄P[1;1;2]⋄P[2;3;1]", "1 3 6\n1 3 7\n2 5 6", 0},
+	{"P←list→product (1 2;3 4 5;6 7;)⋄⍴P[2;3;2]", "3", 0},
+	{"list→product (`a`b;1 2;)", "a 1 a 2\nb 1 b 2", 0},
+	{"list→product (1 2;(3;4 5;);)", "1 3 (1;4 5;)\n2 3 (2;4 5;)", 0}, // tuples with arrays are lists
+	{"⍴list→product 1 2 3", "3", 0},
+	{"P←list→product 1 2 3⋄⍴P[2]", "1", 0},
+	{"list→product (1 2;⍳0;)", "", 0},
+	{"⍴list→product (1 2;⍳0;)", "0", 0},
+	{"list→product (2 2⍴1;1;)", "fail: product: must be a vector", 0},
+
+	{"⍝ Permutations and combinations", "apl/comb/comb.go", 0},
+	{"comb→perm 1 2 3", "(1 2 3;1 3 2;2 1 3;2 3 1;3 1 2;3 2 1;)", 0},
+	{"comb→perm 'AB'", "(A B;B A;)", 0},
+	{"⎕IO←0⋄comb→perm 3", "(0 1 2;0 2 1;1 0 2;1 2 0;2 0 1;2 1 0;)", 0},
+	{"≢comb→perm 5", "120", 0},
+	{"≢comb→perm ⍳0", "1", 0}, // a single empty permutation
+	{"2 comb→choose 1 2 3 4", "(1 2;1 3;1 4;2 3;2 4;3 4;)", 0},
+	{"2 comb→choose 4", "(1 2;1 3;1 4;2 3;2 4;3 4;)", 0},
+	{"⎕IO←0⋄3 comb→choose 4", "(0 1 2;0 1 3;0 2 3;1 2 3;)", 0},
+	{"5 comb→choose 1 2 3", "()", 0},
+	{"≢0 comb→choose 1 2 3", "1", 0},
+	{"comb→perm (1 2;3;)", "((1 2;3;);(3;1 2;);)", 0},
+	{"¯1 comb→choose 3", "fail: choose: L must be a non-negative integer", 0},
+	{"comb→perm 2 2⍴1", "fail: perm: argument must be a vector", 0},
+
+	{"⍝ Base conversion", "apl/base/base.go", 0},
+	{"16 base→to 255", "15 15", 0},
+	{"16 base→to 4096", "1 0 0 0", 0},
+	{"2 base→to 5", "1 0 1", 0},
+	{"2 base→to 0", "0", 0},
+	{"2 base→to 5 10", "0 1\n1 0\n0 1\n1 0", 0},
+	{"16 base→from 15 15", "255", 0},
+	{"16 base→from 1 0 0 0", "4096", 0},
+	{"2 base→from 1 0 1", "5", 0},
+	{"2 base→from 2 base→to 1000", "1000", 0},
+	{"'0123456789ABCDEF'[⎕IO+16 base→to 48879]", "B E E F", 0},
+	{"1 base→to 5", "fail: to: base must be an integer larger than 1", 0},
+	{"2 base→to ¯5", "fail: to: argument must be a non-negative integer", 0},
+
+	{"⍝ Bit count", "apl/bits/popcount.go", 0},
+	{"bits→popcount 7", "3", 0},
+	{"bits→popcount 0 1 2 3 255 256", "0 1 1 2 8 1", 0},
+	{"bits→popcount 2 2⍴1 3 7 15", "1 2\n3 4", 0},
+	{"bits→popcount 1 0 1", "1 0 1", 0},
+	{"bits→popcount ¯1", "64", 0}, // two's complement over 64 bits
+	{"bits→popcount ¯2 ¯256", "63 56", 0},
+	{"bits→popcount ¯1+2*100", "100", rational},
+	{"bits→popcount 1E3", "6", 0},
+	{"bits→popcount 4÷2", "1", 0},
+	{"bits→popcount 1.5", "fail: popcount: argument must be an integer", 0},
+
+	{"⍝ Signal processing", "apl/dsp/register.go", 0},
+	{"dsp→fft 1 0 0 0", "1J0 1J0 1J0 1J0", small},
+	{"dsp→fft 1 2 3 4", "10J0 ¯2J2 ¯2J0 ¯2J¯2", small},
+	{"dsp→fft 1 1 1 1 1 1 1 1", "8J0 0J0 0J0 0J0 0J0 0J0 0J0 0J0", small},
+	{"⌊0.5+1E3×9○dsp→fft 2○○2×(¯1+⍳8)÷8", "0 4000 0 0 0 0 0 4000", small},
+	{"⌊0.5+1E3×11○dsp→fft 1○○2×(¯1+⍳8)÷8", "0 ¯4000 0 0 0 0 0 4000", small},
+	{"dsp→fft 5", "5J0", small},
+	{"dsp→fft ⍳0", "", small},
+	{"dsp→fft 1 2 3", "fail: fft: length is not a power of 2: 3", small},
+	{"dsp→fft 2 2⍴1", "fail: fft: argument must be a vector", small},
+	{"dsp→fft 3↑1 2 3 0", "fail: fft: length is not a power of 2", small},
+	{"dsp→fft 4↑1 2 3", "6J0 ¯2J¯2 2J0 ¯2J2", small},
+	{"⌊0.5+9○dsp→ifft 10 ¯2J2 ¯2 ¯2J¯2", "1 2 3 4", small},
+	{"⌊0.5+1E3×9○dsp→ifft dsp→fft 1 2 3 4", "1000 2000 3000 4000", small},
+	{"1E¯9>⌈/|(1J2×⍳16)-dsp→ifft dsp→fft 1J2×⍳16", "1", small},
+	{"1E¯9>⌈/|(⍳32)-dsp→fft dsp→ifft ⍳32", "1", small},
+	{"1 2 3 dsp→conv 0 1 0.5", "0 1 2.5 4 1.5", float},
+	{"1 2 3 dsp→conv 1 1", "1 3 5 3", 0},
+	{"1 1 dsp→conv 1 2 3", "1 3 5 3", 0},
+	{"(1 2 3;'same';) dsp→conv 0 1 0.5", "1 2.5 4", float},
+	{"(1 2 3;'same';) dsp→conv 1 1", "1 3 5", 0},
+	{"(1 2 3 4 5;'valid';) dsp→conv 1 0 ¯1", "2 2 2", 0},
+	{"(1 2 3;\"full\";) dsp→conv 2", "2 4 6", 0},
+	{"1 2 dsp→conv 1J1 2", "1J1 4J2 4J0", small},
+	{"(1 2 3;'wide';) dsp→conv 1 1", "fail: conv: unknown mode: wide", 0},
+	{"dsp→conv 1 2", "fail: conv: left argument missing", 0},
+	{"1 2 3 dsp→corr 0 1 0.5", "0.5 2 3.5 3 0", float},
+	{"(1 2 3;'same';) dsp→corr 0 1 0.5", "2 3.5 3", float},
+	{"(1 2 3 4;'valid';) dsp→corr 1 1", "3 5 7", 0},
+	{"1 2 dsp→corr 0J1 1", "1J0 2J¯1 0J¯2", small},
+	{"dsp→hann 5⍴1", "0 0.5 1 0.5 0", small},
+	{"dsp→hamming 5⍴1", "0.08 0.54 1 0.54 0.08", small},
+	{"(1↑W),¯1↑W←dsp→hann 64⍴1", "0 0", small},
+	{"(1↑W),¯1↑W←dsp→hamming 64⍴1", "0.08 0.08", small},
+	{"dsp→hann 2 4 6 4 2", "0 2 6 2 0", small},
+	{"dsp→hann 3", "3", small},
+	{"dsp→hann 1J1 2", "fail: hann: argument must be real", small},
+	{"2 dsp→decimate 1 2 3 4 5 6", "1 3 5", 0},
+	{"2 dsp→decimate 1 2 3 4 5", "1 3 5", 0},
+	{"3 dsp→decimate ⍳10", "1 4 7 10", 0},
+	{"0 dsp→decimate ⍳10", "fail: decimate: left argument must be a positive integer", 0},
+	{"3 dsp→resample 1 2 3 4 5", "1 3 5", small},
+	{"5 dsp→resample 0 4", "0 1 2 3 4", small},
+	{"4 dsp→resample 0 3 9", "0 2 5 9", small},
+	{"3 dsp→resample 7", "7 7 7", small},
+
+	{"⍝ Join frames", "apl/frame/join.go", 0},
+	{"A←`id`x#(1 2 3 4;10 20 30 40;)⋄B←`id`y#(4 2 5;400 200 500;)⋄`id frame→join (A;B;)", "id x y\n2 20 200\n4 40 400", 0},
+	{"A←`id`x#(1 2 3 4;10 20 30 40;)⋄B←`id`y#(4 2 5;400 200 500;)⋄`id frame→ljoin (A;B;)", "id x y\n1 10 null\n2 20 200\n3 30 null\n4 40 400", 0},
+	{"A←`id`x#(1 2 3 4;10 20 30 40;)⋄B←`y`id`z#(1 2;3 1;`a`b;)⋄`id frame→join (A;B;)", "id x y z\n1 10 2 b\n3 30 1 a", 0},
+	{"A←`k`v#(`a`b`c;1 2 3;)⋄B←`k`w#(`c`a`a;7 8 9;)⋄`k frame→join (A;B;)", "k v w\na 1 8\nc 3 7", 0},
+	{"A←`k`v#(`a`b;1 2;)⋄B←`k`w#(`x`y;7 8;)⋄`k frame→ljoin (A;B;)", "k v w\na 1 null\nb 2 null", 0},
+	{"A←`k`v#(`a`b;1 2;)⋄B←`k`w#(`x`y;7 8;)⋄⍴(`k frame→join (A;B;))[`v]", "0", 0},
+	{"A←⍉`id`x#(1 2 3;4 5 6;)⋄B←⍉`id`y#(3 1;7 8;)⋄`id frame→join (A;B;)", "id x y\n1 4 8\n3 6 7", 0},
+	{"A←`id`x#(1 2;3 4;)⋄B←`id`x#(1 2;5 6;)⋄`id frame→join (A;B;)", "fail: join: duplicate column: x", 0},
+	{"A←`id`x#(1 2;3 4;)⋄B←`id`y#(1 2;5 6;)⋄`z frame→join (A;B;)", "fail: join: key column does not exist: z", 0},
+	{"A←`id`x#(1 2;3 4 5;)⋄B←`id`y#(1 2;5 6;)⋄`id frame→join (A;B;)", "fail: join: columns have different lengths", 0},
+	{"A←`id`x#(1 2;3 4;)⋄`id frame→join A", "fail: join: right argument must be a list of two frames", 0},
+	{"A←`id`x#(1 2;3 4;)⋄B←`id`y#(1 2;5 6;)⋄'id' frame→join (A;B;)", "fail: join: left argument must be the key column", 0},
+
 	{"⍝ Lists catenate, enlist, cut, each", "apl/primitives/comma.go", 0},
 	{"1,(2;3;)", "(1;2;3;)", 0},
 	{"(1;2;),3", "(1;2;3;)", 0},
@@ -927,6 +1766,112 @@ var testCases = []struct {
 	{"(1;2;(3;4;);)+¨(1;2;(3;4;);)", "(2;4;6 8;)", 0},
 	{"≢¨(1;2;(3;4;);)", "(1;1;2;)", 0},
 
+	{"⍝ Deep each", "apl/operators/deepeach.go", 0},
+	{"-⍡(1;(2;3 4;);)", "(¯1;(¯2;¯3 ¯4;);)", 0},
+	{"{⍵×10}⍡(1;((2;3;);4 5;);6;)", "(10;((20;30;);40 50;);60;)", 0},
+	{"≢⍡(1;(2;3 4;);)", "(1;(1;1 1;);)", 0},
+	{"-⍡2 3", "¯2 ¯3", 0},
+	{"-⍡5", "¯5", 0},
+	{"1+⍡(1;(2;3 4;);)", "(2;(3;4 5;);)", 0},
+	{"(10;(20;30;);)+⍡(1;(2;3 4;);)", "(11;(22;33 34;);)", 0},
+	{"(1;2;)+⍡(1;2;3;)", "fail: deep each: list lengths differ", 0},
+	{"⍴⍡(1;(2;3 4;);)", "fail: each: result must be a scalar", 0},
+
+	{"⍝ Fold with seed", "apl/operators/fold.go", 0},
+	{"+⍢10⊢1 2 3", "16", 0},
+	{"-⍢0⊢1 2 3", "¯6", 0},
+	{"-/1 2 3", "2", 0},
+	{"+⍢10⊢⍳0", "10", 0},
+	{"-⍢7⊢⍳0", "7", 0},
+	{"+⍢5⊢3", "8", 0},
+	{"{⍺,2×⍵}⍢(⍳0)⊢1 2 3", "2 4 6", 0},
+	{"{⍵,⍺}⍢(⍳0)⊢1 2 3", "3 2 1", 0},
+	{"+⍢0⊢2 3⍴⍳6", "5 7 9", 0},
+	{"+⍢0⊢(1;2 3;)", "3 4", 0},
+	{"1 +⍢0⊢2", "fail: fold cannot be called dyadically", 0},
+
+	{"⍝ Map accumulate, scan with state", "apl/operators/accumulate.go", 0},
+	{"{(⍺⌈⍵),⍺⌈⍵}⍫0⊢3 1 4 1 5 9 2 6", "(9;3 3 4 4 5 9 9 9;)", 0},
+	{"{S←(⍵≠0)×⍺+⍵ ⋄ S S}⍫0⊢1 2 0 3 4 0 5", "(5;1 3 0 3 7 0 5;)", 0},
+	{"{(⍺+1),⍺×⍵}⍫1⊢5 5 5", "(4;5 10 15;)", 0},
+	{"{(⍺+⍵;⍺;)}⍫0⊢1 2 3", "(6;0 1 3;)", 0},
+	{"{(⍺+1;⍵ ⍵;)}⍫0⊢1 2", "(2;(1 1;2 2;);)", 0},
+	{"{⍺+⍵}⍫0⊢1 2", "fail: map accumulate: function must return a pair", 0},
+	{"+⍫0⊢⍳0", "(0;;)", 0},
+
+	{"⍝ Sliding window", "apl/operators/window.go", 0},
+	{"3 {S←⍵[⍋⍵] ⋄ S[2]}⌻ 5 1 4 2 3 9 0", "4 2 3 3 3", 0},
+	{"m←{S←⍵[⍋⍵] ⋄ S[⌈0.5×⍴⍵]} ⋄ 5 m⌻ 7 1 3 9 4 8 2", "4 4 4", 0},
+	{"2 {+/⍵}⌻ ⍳6", "3 5 7 9 11", 0},
+	{"2+/⍳6", "3 5 7 9 11", 0},
+	{"2 2 {+/⍵}⌻ ⍳6", "3 7 11", 0},
+	{"3 2 {+/⍵}⌻ ⍳6", "6 12", 0},
+	{"2 ⊢⌻ ⍳4", "(1 2;2 3;3 4;)", 0},
+	{"2 {⍴⍵}⌻ 4 3⍴⍳12", "(2 3;2 3;2 3;)", 0},
+	{"2 {+⌿⍵}⌻ 3 2⍴⍳6", "(4 6;8 10;)", 0},
+	{"2 {+/⍵}⌻ (1;2;3;)", "3 5", 0},
+	{"5 {+/⍵}⌻ ⍳3", "", 0},
+	{"0 {+/⍵}⌻ ⍳3", "fail: sliding window: size and step must be positive", 0},
+
+	{"⍝ Take while, drop while", "apl/operators/while.go", 0},
+	{"(3∘>)⍐1 2 3 4 1", "1 2", 0},
+	{"(3∘>)⍗1 2 3 4 1", "3 4 1", 0},
+	{"⍴(9∘<)⍐1 2 3", "0", 0},
+	{"(9∘<)⍗1 2 3", "1 2 3", 0},
+	{"(9∘>)⍐1 2 3", "1 2 3", 0},
+	{"⍴(9∘>)⍗1 2 3", "0", 0},
+	{"{⍵≠' '}⍐'abc def'", "a b c", 0},
+	{"{⍵=' '}⍗'  abc'", "a b c", 0},
+	{"{2>+/⍵}⍐3 2⍴0 1 1 0 1 1", "0 1\n1 0", 0},
+	{"{2>≢⍵}⍗(1;2;3 4;5;)", "(3 4;5;)", 0},
+	{"{2>≢⍵}⍐(1;2;3 4;5;)", "(1;2;)", 0},
+	{"(3∘>)⍐1", "1", 0},
+	{"⍴(3∘>)⍗1", "0", 0},
+	{"{⍵}⍐1 2 3", "fail: take while: predicate must return a boolean", 0},
+
+	{"⍝ Group by", "apl/operators/group.go", 0},
+	{"(2∘|)⌸⍳6", "1: 1 3 5\n0: 2 4 6", 0},
+	{"D←(2∘|)⌸⍳6 ⋄ D[0]", "2 4 6", small},
+	{"{⍵[1]}⌸('apple';'avocado';'bean';'cherry';'banana';)", "a: (a p p l e;a v o c a d o;)\nb: (b e a n;b a n a n a;)\nc: (c h e r r y;)", 0},
+	{"{⍵>2}⌸1 5 2 7", "0: 1 2\n1: 5 7", 0},
+	{"⊢⌸3 1 3 3", "3: 3 3 3\n1: 1", 0},
+	{"{⍵[1]}⌸3 2⍴1 2 3 4 1 5", "1: 1 2\n1 5\n3: 3 4", 0},
+	{"⍴⍴(2∘|)⌸⍳0", "1", 0},
+	{"{2 2}⌸1 2", "fail: group by: key must be a scalar", 0},
+	{"D←`cat`qty#(`a`b`a`c`b;1 2 3 4 5;) ⋄ `cat (+/)⌸D", "cat qty\na 4\nb 7\nc 4", 0},
+	{"D←`cat`qty`n#(`a`b`a`c`b;1 2 3 4 5;1 1 1 1 1;) ⋄ `cat (+/)⌸D", "cat qty n\na 4 2\nb 7 2\nc 4 1", 0},
+	{"D←`qty`cat#(1 2 3 4;1 2 1 1;) ⋄ `cat (⌈/)⌸D", "qty cat\n4 1\n2 2", 0},
+	{"D←`cat`qty#(`a`b`a;1 2 3;) ⋄ (`cat (+/)⌸D)[`qty]", "4 2", 0},
+	{"T←⍉`cat`qty#(`a`b`a;1 2 3;) ⋄ `cat (≢)⌸T", "cat qty\na 2\nb 1", 0},
+	{"D←`cat`qty#(`a`b`a;1 2 3;) ⋄ `cat {(+/⍵)÷≢⍵}⌸(D[`qty]>1)/D", "cat qty\nb 2\na 3", 0},
+	{"D←`cat`qty#(`a`b`a;1 2 3;) ⋄ `x (+/)⌸D", "fail: group by: key column must be a vector", 0},
+	{"D←`cat`qty#(`a`b`a;1 2 3;) ⋄ 'cat' (+/)⌸D", "fail: group by: left argument must be a key column", 0},
+
+	{"⍝ Memoize", "apl/operators/memo.go", 0},
+	{"fib←{⍵<2:⍵ ⋄ (fib ⍵-1)+fib ⍵-2}⍥ ⋄ fib¨⍳10", "1 1 2 3 5 8 13 21 34 55", 0},
+	{"fib←{⍵<2:⍵ ⋄ (fib ⍵-1)+fib ⍵-2}⍥ ⋄ fib 80", "23416728348467685", small},
+	{"f←{⎕←⍵ ⋄ 2×⍵}⍥ ⋄ f 3 ⋄ f 3 ⋄ f 3 4 ⋄ f 3 4", "3\n6\n6\n3 4\n6 8\n6 8", 0},
+	{"f←{⎕←⍺ ⋄ ⍺+⍵}⍥ ⋄ 1 f 2 ⋄ 1 f 2 ⋄ 2 f 2 ⋄ 2 f 2", "1\n3\n3\n2\n4\n4", 0},
+	{"f←{⎕←⍵ ⋄ ⍵}⍥ ⋄ f 1 ⋄ f ,1", "1\n1\n1\n1", 0},
+	{"f←(+/)⍥ ⋄ f 1 2 3", "6", 0},
+
+	{"⍝ Conditional function selection", "apl/operators/cond.go", 0},
+	{"(0∘<)⍰{⍵×10}⍠- 9", "90", 0},
+	{"(0∘<)⍰{⍵×10}⍠- ¯9", "9", 0},
+	{"(0∘<)⍰{⍵×10}⍠-¨¯4 9 ¯1", "4 90 1", 0},
+	{"{∧/⍵>0}⍰{⍵×10}⍠{⍵-1} 1 2 3", "10 20 30", 0},
+	{"{∧/⍵>0}⍰{⍵×10}⍠{⍵-1} 1 ¯2 3", "0 ¯3 2", 0},
+	{"f←(0∘>)⍰-⍠⊢ ⋄ f¨¯1 2 ¯3", "1 2 3", 0},
+	{"(0∘<)⍰-⍠((0∘>)⍰+⍠{100})¨¯5 0 5", "¯5 100 ¯5", 0},
+	{"1 <⍰+⍠- 2", "3", 0},
+	{"2 <⍰+⍠- 1", "1", 0},
+	{"(0∘<)⍰- 5", "¯5", 0},
+	{"(0∘<)⍰- ¯5", "fail: condition is false", 0},
+	{"(0∘<)⍰((0∘>)⍰-)⍠{100} 5", "fail: condition is false", 0},
+	{"(0∘<)⍰((0∘>)⍰-)⍠{100} ¯5", "100", 0},
+	{"(0∘<)⍰-⍠((0∘>)⍰+)⍠{100} 0", "fail: condition is false", 0},
+	{"(0∘<)⍰-⍠+ 1 2", "fail: if: predicate must return a boolean scalar", 0},
+
 	{"⍝ List indexing", "apl/primitives/index.go", 0},
 	{"L←(1;2;)⋄L[2]", "2", 0},
 	{"L←(1;(2;3;);4;)⋄L[2;1]", "2", 0},
@@ -949,11 +1894,31 @@ var testCases = []struct {
 	{"D←`a`b`c#1 2 3⋄G←D[`a`c]⋄G", "a: 1\nc: 3", 0},
 	{"D←`a`b#(1;(`c`d#`F`G);)⋄D[`b;`d]←123⋄D[`b]", "c: F\nd: 123", 0},
 	{"D←`a`b#(1;2;)⋄D[`b]+←3⋄D", "a: 1\nb: 5", 0},
+	{"`x`y`z#(1 2 3;4 5 6;7 8 9;)", "x y z\n1 4 7\n2 5 8\n3 6 9", small},
+	{"`x`y`z#(1 2 3;4 5;6;)", "x: 1 2 3\ny: 4 5\nz: 6", small},
+	{"`x`y#(1 2;3;)", "x: 1 2\ny: 3", small},
+	{"1 2#(3 4;5 6;)", "1: 3 4\n2: 5 6", small},
+
+	{"⍝ Column operations on dicts of vectors", "apl/operators/reduce.go", 0},
+	{"D←`a`b`c#(1 2 3 4;5 6 7 8;9 10 11 12;)⋄D[`a`c]", "a c\n1 9\n2 10\n3 11\n4 12", small},
+	{"D←`a`b`c#(1 2 3 4;5 6 7 8;9 10 11 12;)⋄(D[`a]>2)/D", "a b c\n3 7 11\n4 8 12", small},
+	{"D←`a`b`c#(1 2 3 4;5 6 7 8;9 10 11 12;)⋄(2|D[`b])/D[`a`c]", "a c\n1 9\n3 11", small},
+	{"D←`a`b#(1 2 3;4 5 6;)⋄2 0 1/D", "a b\n1 4\n1 4\n3 6", small},
+	{"D←`a`b#(1 2 3;4 5 6;)⋄1 0 1⌿D", "a b\n1 4\n3 6", small},
+	{"D←`a`b#(1 2 3;4 5 6;)⋄0 1 0/D", "a: 2\nb: 5", small},
+	{"D←`a`b#(1 2 3;4 5 6;)⋄+/¨D", "a: 6\nb: 15", small},
+	{"D←`a`b#(1 2 3;4 5 6;)⋄{⍵×10}¨D", "a b\n10 40\n20 50\n30 60", small},
+	{"D←`a`b#(1 2 3;4 5 6;)⋄{⍵[1]}¨D", "a: 1\nb: 4", small},
+	{"D←`a`b#(1 2 3;4 5 6;)⋄⌽¨D", "a b\n3 6\n2 5\n1 4", small},
+	{"D←`a`b#(1 2 3;4 5 6;)⋄{⌽⍵}¨(D[`a]≠2)/D", "a b\n3 6\n1 4", small},
+	{"T←⍉`a`b#(1 2 3;4 5 6;)⋄1 0 1/T", "a b\n1 4\n3 6", small},
+	{"D←`a`b#(1 2 3;4;)⋄1 0 1/D", "fail: replicate: dict values must be vectors", small},
+	{"D←`a`b#(1 2 3;4 5;)⋄1 0 1/D", "fail: replicate: length of L must conform", small},
 
 	{"⍝ Table, transpose a dict to create a table", "apl/primitives/transpose.go", 0},
 	{"⍉`a`b#1 2", "a b\n1 2", 0},
 	{"⍉`a`b`c#(1 2 3;4 5 6;7 8 9;)", "a b c\n1 4 7\n2 5 8\n3 6 9", small},
-	{"⍉⍉`a`b`c#(1 2 3;4 5 6;7 8 9;)", "a: 1 2 3\nb: 4 5 6\nc: 7 8 9", small},
+	{"⍉⍉`a`b`c#(1 2 3;4 5 6;7 8 9;)", "a b c\n1 4 7\n2 5 8\n3 6 9", small},
 	{"⍴`a`b#(1 2 3;4 5 6;)", "2", 0},
 	{"⍴⍉`a`b#(1 2 3;4 5 6;)", "3 2", small},
 
@@ -987,20 +1952,20 @@ var testCases = []struct {
 	{"T←⍉`a`b#(⍳3;4-⍳3;) ⋄ T[`a`b]←1 ⋄ T", "a b\n1 1\n1 1\n1 1", small},                   // column names are given as first index
 
 	{"⍝ Elementary functions on dicts and tables", "apl/primitives/elementary.go", 0},
-	{"A←`a`b#(1 2;3 4;)⋄-A", "a: ¯1 ¯2\nb: ¯3 ¯4", small},
+	{"A←`a`b#(1 2;3 4;)⋄-A", "a b\n¯1 ¯3\n¯2 ¯4", small},
 	{"A←⍉`a`b#(1 2;3 4;)⋄-A", "a b\n¯1 ¯3\n¯2 ¯4", small},
-	{"A←`a`b#(1 2;3 4;)⋄B←`a`b#(9 8;7 6;)⋄B-A", "a: 8 6\nb: 4 2", small},
-	{"A←`a`b#(1 2;3 4;)⋄B←`b`c#(9 8;7 6;)⋄B-A", "b: 6 4\nc: 7 6\na: ¯1 ¯2", small},
+	{"A←`a`b#(1 2;3 4;)⋄B←`a`b#(9 8;7 6;)⋄B-A", "a b\n8 4\n6 2", small},
+	{"A←`a`b#(1 2;3 4;)⋄B←`b`c#(9 8;7 6;)⋄B-A", "b c a\n6 7 ¯1\n4 6 ¯2", small},
 	{"A←⍉`a`b#(1 2;3 4;)⋄B←⍉`b`c#(9 8;7 6;)⋄B-A", "b c a\n6 7 ¯1\n4 6 ¯2", small},
 	{"A←⍉`a`b#(1 2;3 4;)⋄A-3", "a b\n¯2 0\n¯1 1", small},
-	{"A←`a`b#(1 2;3 4;)⋄A-5 7", "a: ¯4 ¯5\nb: ¯2 ¯3", small},
-	{"A←`a`b#(1 2;3 4;)⋄3-A", "a: 2 1\nb: 0 ¯1", small},
+	{"A←`a`b#(1 2;3 4;)⋄A-5 7", "a b\n¯4 ¯2\n¯5 ¯3", small},
+	{"A←`a`b#(1 2;3 4;)⋄3-A", "a b\n2 0\n1 ¯1", small},
 
 	{"⍝ Catenate tables or objects", "apl/primitives/comma.go", 0},
-	{"A←`a`b#(1 2;3 4;)⋄B←`a`b#(5 6;7 8;)⋄A,B", "a: 1 2 5 6\nb: 3 4 7 8", small},
+	{"A←`a`b#(1 2;3 4;)⋄B←`a`b#(5 6;7 8;)⋄A,B", "a b\n1 3\n2 4\n5 7\n6 8", small},
 	{"A←`a`b#(1 2;3 4;)⋄B←`b`c#(5 6;7 8;)⋄A,B", "a: 1 2\nb: 3 4 5 6\nc: 7 8", small},
-	{"A←`a`b#(1 2;3 4;)⋄B←`a`b#(5 6;7 8;)⋄A⍪B", "a: 5 6\nb: 7 8", small},
-	{"A←`a`b#(1 2;3 4;)⋄B←`b`c#(5 6;7 8;)⋄A⍪B", "a: 1 2\nb: 5 6\nc: 7 8", small},
+	{"A←`a`b#(1 2;3 4;)⋄B←`a`b#(5 6;7 8;)⋄A⍪B", "a b\n5 7\n6 8", small},
+	{"A←`a`b#(1 2;3 4;)⋄B←`b`c#(5 6;7 8;)⋄A⍪B", "a b c\n1 5 7\n2 6 8", small},
 	{"A←⍉`a`b#(1 2;3 4;)⋄B←⍉`a`b#(5 6;7 8;)⋄A,B", "a b\n5 7\n6 8", small},
 	{"A←⍉`a`b#(1 2;3 4;)⋄B←⍉`b`c#(5 6;7 8;)⋄A,B", "a b c\n1 5 7\n2 6 8", small},
 	{"A←⍉`a`b#(1 2;3 4;)⋄B←⍉`a`b#(5 6;7 8;)⋄A⍪B", "a b\n1 3\n2 4\n5 7\n6 8", small},
@@ -1008,15 +1973,15 @@ var testCases = []struct {
 	{"A←⍉`a`b#(1 2;3 4;)⋄A⍪5", "a b\n1 3\n2 4\n5 5", small},
 	{"A←⍉`a`b#(1 2;3 4;)⋄A,5 6", "a b\n1 3\n2 4\n5 5\n6 6", small},
 	{"A←⍉`a`b#(1 2;3 4;)⋄5 6,A", "a b\n5 5\n6 6\n1 3\n2 4", small},
-	{"A←`a`b#(1 2;3 4;)⋄A,5", "a: 1 2 5\nb: 3 4 5", small},
-	{"A←`a`b#(1 2;3 4;)⋄5 6⍪A", "a: 5 6 1 2\nb: 5 6 3 4", small},
+	{"A←`a`b#(1 2;3 4;)⋄A,5", "a b\n1 3\n2 4\n5 5", small},
+	{"A←`a`b#(1 2;3 4;)⋄5 6⍪A", "a b\n5 5\n6 6\n1 3\n2 4", small},
 
 	{"⍝ Reduction over objects and tables", "apl/operators/reduce.go", 0},
 	{"+/`a`b`c#(1 2 3;4 6;7;)", "a: 6\nb: 10\nc: 7", small},
 	{"+\\`a`b`c#(1 2 3;4 6;7;)", "a: 1 3 6\nb: 4 10\nc: 7", small},
 	{"+/⍉`a`b`c#(1 2 3;4 5 6;7 8 9;)", "a b c\n6 15 24", small},
 	{"+\\⍉`a`b`c#(1 2 3;4 5 6;7 8 9;)", "a b c\n1 4 7\n3 9 15\n6 15 24", small},
-	{"2+/`a`b#(1 2 3;4 6 7;)", "a: 3 5\nb: 10 13", small},
+	{"2+/`a`b#(1 2 3;4 6 7;)", "a b\n3 10\n5 13", small},
 	{"2+/⍉`a`b#(1 2 3;4 6 7;)", "a b\n3 10\n5 13", small},
 	{"T←⍉`a`b`c#(1 2 3;4 5 6;7 8 9;)⋄T⍪(+⌿÷≢)T", "a b c\n1 4 7\n2 5 8\n3 6 9\n2 5 8", small},
 
@@ -1025,7 +1990,53 @@ var testCases = []struct {
 	{"X←go→t 0⋄X[`I]←55⋄X[`inc]⍨0⋄X[`I]", "56", small},
 	{"X←go→t 0⋄X[`V]←'abcd'⋄X[`join]⍨'+'", "(4;a+b+c+d;)", small},
 	{"S←go→s 0⋄#[1]S", "sum", 0},
-	{"T←go→t 0⋄T[`S;`A]←3⋄T[`S;`V]←2 3⋄T[`S]", "A: 3\nB: 0\nV: 2 3", 0},
+	{"T←go→t 0⋄T[`S;`A]←3⋄T[`S;`V]←2 3⋄T[`S]", "A: 3\nB: 0\nV: 2 3\nsum: method() int", 0},
+	{"X←go→t 0⋄X[`join]", "Join(string) (int, string)", 0},
+	{"X←go→t 0⋄X[`setS]⍨`A`B#1 2⋄X[`S]", "A: 1\nB: 2\nV: \nsum: method() int", 0},
+	{"X←go→t 0⋄X[`setS]⍨`a`v#(3;4 5;)⋄X[`S;`A`V]", "A: 3\nV: 4 5", 0},
+	{"X←go→t 0⋄X[`setS]⍨1 2⋄(X[`S])[`sum]⍨0", "3", 0},
+	{"X←go→t 0⋄X[`setS]⍨(1;2;3 4;)⋄X[`S;`V]", "3 4", 0},
+	{"X←go→t 0⋄X[`setS]⍨`A`C#1 2", "fail: xgo: export struct xgo.S: field does not exist: C", 0},
+	{"X←go→t 0⋄X[`setS]⍨1 2 3 4", "fail: xgo: export struct xgo.S: too many values: 4 > 3 fields", 0},
+	{"X←go→t 0⋄X[`P]←3 2⍴⍳6⋄⍴X[`P]", "3", 0},
+	{"X←go→t 0⋄X[`P]←3 2⍴⍳6⋄(X[`P])[2]", "X: 3\nY: 4", 0},
+	{"X←go→t 0⋄X[`P]←((1;2;);(3;4;);)⋄P←X[`P]⋄(P[2])[`Y]", "4", 0},
+	{"X←go→t 0⋄X[`P]←(`x`y#1 2;`y#4;)⋄(X[`P])[2]", "X: 0\nY: 4", 0},
+	{"X←go→t 0⋄X[`P]←3 2⍴⍳6⋄X[`P]←⌽X[`P]⋄(X[`P])[1]", "X: 5\nY: 6", 0},
+	{"X←go→t 0⋄X[`P]←(1 2;1 2 3;)", "fail: assign X: []xgo.Point[1]: xgo: export struct xgo.Point: too many values: 3 > 2 fields", 0},
+	{"X←go→t 0⋄X[`P]←(`X`Y#1 2;`X`Z#3 4;)", "fail: assign X: []xgo.Point[1]: xgo: export struct xgo.Point: field does not exist: Z", 0},
+	{"X←go→t 0⋄X[`E]", "null", 0},
+	{"X←go→t 0⋄X[`E]←5⋄X[`E]⋄X[`dynamic]⍨0", "5\nint", small},
+	{"X←go→t 0⋄X[`E]←5⋄X[`E]←\"abc\"⋄X[`E]⋄X[`dynamic]⍨0", "abc\nstring", 0},
+	{"X←go→t 0⋄X[`E]←1.5⋄X[`dynamic]⍨0", "float64", small},
+	{"X←go→t 0⋄X[`E]←1 2 3⋄X[`E]⋄X[`dynamic]⍨0", "1 2 3\n[]interface {}", small},
+	{"X←go→t 0⋄X[`E]←go→s 0⋄X[`E;`B]←3⋄X[`dynamic]⍨0⋄X[`E;`B]", "*xgo.S\n3", 0},
+	{"X←go→t 0⋄X[`E]←\"abc\"⋄X[`E]←⎕NULL⋄X[`dynamic]⍨0", "<nil>", 0},
+	{"X←go→t 0⋄X[`E]←{⍵}", "fail: assign X: xgo: export interface: cannot convert *apl.lambda to interface {}", 0},
+	{"X←go→t 0⋄X[`In]", "null", 0},
+	{"X←go→t 0⋄X[`In]←`A#3⋄X[`In]", "A: 3\nNext: null", 0},
+	{"X←go→t 0⋄X[`In]←`A#3⋄X[`In;`A]←4⋄X[`In;`A]", "4", 0},
+	{"X←go→t 0⋄X[`In]←`A#3⋄I←X[`In]⋄I[`A]←5⋄X[`In;`A]", "5", 0},
+	{"X←go→t 0⋄X[`In]←`A`Next#(3;`A#5;)⋄X[`In;`Next;`A]", "5", 0},
+	{"X←go→t 0⋄X[`In]←`A#3⋄X[`In]←⎕NULL⋄X[`In]", "null", 0},
+	{"X←go→t 0⋄X[`In;`A]←4", "fail: obj depth sel: cannot index into apl.Null", 0},
+	{"X←go→t 0⋄X[`In]←`A#3⋄X[`In;`Next;`A]", "fail: obj-depth-index: cannot index into apl.Null", 0},
+	{"X←go→t 0⋄X[`A]←\"alpha=beta\"⋄X[`find]⍨\"=\"", "(5;beta;1;)", 0},
+	{"X←go→t 0⋄X[`A]←\"alpha\"⋄X[`find]⍨\"=\"", "(¯1;;0;)", 0},
+	{"X←go→t 0⋄X[`A]←\"alpha=beta\"⋄R←X[`mustFind]⍨\"=\"⋄≢R", "3", 0},
+	{"X←go→t 0⋄X[`A]←\"alpha\"⋄X[`mustFind]⍨\"=\"", "fail: \"=\" not found", 0},
+	{"X←go→t 0⋄X[`inc]", "Inc()", 0},
+	{"a→p \"s→toupper\"", "ToUpper(string) string", 0},
+	{"a→p \"s→splitn\"", "SplitN(string, string, int) []string", 0},
+
+	{"⍝ Go channel fields", "apl/xgo/type.go", 0},
+	{"X←go→t 0⋄X[`open]⍨3⋄X[`Ch]←5⋄X[`Ch]←6⋄X[`Ch]", "5", 0},
+	{"X←go→t 0⋄X[`open]⍨3⋄X[`Ch]←5⋄X[`Ch]←6⋄X[`Ch]+X[`Ch]", "11", 0},
+	{"X←go→t 0⋄X[`open]⍨2⋄X[`Ch]←5⋄X[`close]⍨0⋄X[`Ch]⋄⍴X[`Ch]", "5\n0", 0},
+	{"X←go→t 0⋄X[`open]⍨1⋄X[`close]⍨0⋄X[`Ch]←1", "fail: send on closed channel", 0},
+	{"X←go→t 0⋄X[`Ch]←1", "fail: send to nil channel", 0},
+	{"X←go→t 0⋄X[`open]⍨1⋄X[`Ch]←5⋄X[`Ch `I]⋄X[`Ch]", "Ch: chan int\nI: 0\n5", 0}, // At does not receive
+	{"X←go→t 0⋄X[`Ch]", "fail: cannot receive from channel", 0},
 
 	{"⍝ Channels read, write and close", "apl/primitives/take.go", 0},
 	{"C←go→source 6⋄2 3↑C", "0 1 2\n3 4 5", 0},
@@ -1041,6 +2052,56 @@ var testCases = []struct {
 	{"<¨⍳3", "1\n2\n3", 0},                                 // channel-each
 	{"(<⍤2)2 2 3⍴⍳12", "1 2 3\n4 5 6\n7 8 9\n10 11 12", 0}, // channel-rank
 
+	{"⍝ Error values, catch and signal", "apl/error.go", 0},
+	{"{÷⍵}⎊{⍺[`message]}'a'", "÷: not a numeric type apl.String", 0},
+	{"{÷⍵}⎊{⍺[`code]}'a'", "0", 0},
+	{"{÷⍵}⎊{⍵}'a'", "a", 0},
+	{"{-⍵}⎊{⍺}2", "¯2", 0},
+	{"{↯\"alpha\"}⎊{⍺[`message]}0", "alpha", 0},
+	{"{11↯\"alpha\"}⎊{⍺[`code]}0", "11", 0},
+	{"E←{11↯\"alpha\"}⎊{⍺}0 ⋄ #E", "message code", 0},
+	{"E←{11↯\"alpha\"}⎊{⍺}0 ⋄ E[`message`code]", "message: alpha\ncode: 11", 0},
+	{"E←{11↯\"alpha\"}⎊{⍺}0 ⋄ {↯E}⎊{⍺[`code]}0", "11", 0},
+	{"{↯\"alpha\"}⎊{↯⍺}0", "fail: alpha", 0},
+	{"↯\"alpha\"", "fail: alpha", 0},
+	{"↯'alpha'", "fail: alpha", 0},
+	{"{11↯'alpha'}⎊{⍺[`message`code]}0", "message: alpha\ncode: 11", 0},
+	{"↯2 2⍴'abcd'", "fail: signal: R must be a string or an error value", 0},
+	{"↯1", "fail: signal: R must be a string or an error value", 0},
+	{"E←{11↯\"alpha\"}⎊{⍺}0 ⋄ E[`code]←1", "fail: error values are read-only", 0},
+	{"2 {⍺÷⍵}⎊{⍺[`message]} 'a'", "÷: right argument is not a numeric type apl.String", 0},
+
+	{"⍝ Spawn", "apl/operators/spawn.go", 0},
+	{"A←{+/⍳⍵}&100 ⋄ B←{×/⍳⍵}&5 ⋄ (↑A),↑B", "5050 120", 0},
+	{"C←2 {⍺×⍵}&3 ⋄ ↑C", "6", 0},
+	{"F←{⍵×2}&¨⍳3 ⋄ ↑¨F", "2 4 6", 0},
+	{"X←1 ⋄ C←{X←⍵}&2 ⋄ ↑C ⋄ X", "2\n1", 0},
+	{"X←5 ⋄ C←{X+⍵}&2 ⋄ ↑C", "7", 0},
+	{"C←{⍵÷0}&'a' ⋄ ↑C", "fail: ", 0},
+
+	{"⍝ Shared counter and mutex", "apl/xgo/sync.go", 0},
+	{"C←go→counter 0 ⋄ C[`inc]⍨0 ⋄ C[`add]⍨5 ⋄ C[`get]⍨0", "1\n6\n6", 0},
+	{"C←go→counter 0 ⋄ F←{C[`inc]⍨0}&¨⍳100 ⋄ X←↑¨F ⋄ C[`get]⍨0", "100", 0},
+	{"C←go→counter 0 ⋄ F←{C[`add]⍨⍵}&¨⍳100 ⋄ X←↑¨F ⋄ C[`get]⍨0", "5050", 0},
+	{"C←go→counter 0 ⋄ F←{C[`inc]⍨0}&¨⍳10 ⋄ +/↑¨F", "55", 0},
+	{"M←go→mutex 0 ⋄ M[`lock]⍨0 ⋄ M[`unlock]⍨0 ⋄ 1", "1", 0},
+
+	{"⍝ Go io.Reader and io.Writer", "apl/xgo/io.go", 0},
+	{"B←go→buffer 0 ⋄ B go→write 'alpha' ⋄ B go→write \" beta\" ⋄ B[`string]⍨0", "5\n5\nalpha beta", 0},
+	{"B←go→buffer 0 ⋄ X←B go→write 'alpha' ⋄ B[`len]⍨0", "5", 0},
+	{"B←go→buffer 0 ⋄ X←B go→write 'alpha' ⋄ 3 go→read B ⋄ go→read B", "alp\nha", 0},
+	{"R←go→reader \"alpha beta\" ⋄ 3 go→read R ⋄ 100 go→read R ⋄ ⍴100 go→read R", "alp\nha beta\n0", 0},
+	{"R←go→reader \"alpha\" ⋄ go→read R ⋄ ⍴go→read R", "alpha\n0", 0},
+	{"R←go→reader \"alpha\" ⋄ ⍴⍴0 go→read R", "0", 0},
+	{"R←go→reader \"alpha\" ⋄ R go→write 'x'", "fail: write: L must be an io.Writer", 0},
+	{"go→read 1", "fail: read: R must be an io.Reader", 0},
+
+	{"⍝ Go constants", "apl/xgo/const.go", 0},
+	{"color→Red color→Green color→Blue", "0 1 2", 0},
+	{"X←p→palette 0⋄X[`paint]⍨color→Blue", "blue", 0},
+	{"(a→i \"color\")[`name]", "Blue Green Red", 0},
+	{"color→Red←3", "fail: cannot assign to color→Red: package color is read-only", 0},
+
 	{"⍝ Communicate over a channel", "apl/channel.go", 0},
 	{`C←go→echo"?"⋄C↓'a'⋄C↓'b'⋄2↑C⋄↓C`, "a\nb\n?a ?b\n1", 0},
 
@@ -1099,12 +2160,13 @@ func testCompare(got, exp string) bool {
 }
 
 const (
-	float int = 1 << iota // only for floating point towers
-	small                 // normal tower only
+	float    int = 1 << iota // only for floating point towers
+	small                    // normal tower only
+	rational                 // big tower only
 )
 
 func TestNormal(t *testing.T) {
-	testApl(t, nil, 0)
+	testApl(t, nil, rational)
 }
 
 func TestBig(t *testing.T) {
@@ -1118,7 +2180,7 @@ func TestPrecise(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
-	testApl(t, func(a *apl.Apl) { big.SetPreciseTower(a, 256) }, small)
+	testApl(t, func(a *apl.Apl) { big.SetPreciseTower(a, 256) }, small|rational)
 }
 
 func testApl(t *testing.T, tower func(*apl.Apl), skip int) {
@@ -1184,6 +2246,24 @@ func testApl(t *testing.T, tower func(*apl.Apl), skip int) {
 		operators.Register(a)
 		aplstrings.Register(a, "s")
 		xgo.Register(a, "go")
+		list.Register(a, "")
+		b64.Register(a, "")
+		hash.Register(a, "")
+		apla.Register(a, "")
+		set.Register(a, "")
+		comb.Register(a, "")
+		base.Register(a, "")
+		bits.Register(a, "")
+		aplfmt.Register(a, "")
+		a.RegisterPackage("p", map[string]apl.Value{"palette": xgo.New(reflect.TypeOf(Palette{}))})
+		if err := xgo.RegisterConstants(a, "color", map[string]interface{}{"Red": Red, "Green": Green, "Blue": Blue}); err != nil {
+			t.Fatal(err)
+		}
+		date.Register(a, "")
+		cmp.Register(a, "")
+		big.Register(a, "")
+		dsp.Register(a, "")
+		frame.Register(a, "")
 
 		mustfail := strings.HasPrefix(tc.exp, "fail:")
 		lines := strings.Split(tc.in, "\n")
@@ -1215,6 +2295,26 @@ func testApl(t *testing.T, tower func(*apl.Apl), skip int) {
 	}
 }
 
+// Color is an example for typed constants registered with xgo.RegisterConstants.
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+func (c Color) String() string {
+	return [...]string{"red", "green", "blue"}[c]
+}
+
+// Palette has a method with a typed constant as an argument.
+type Palette struct{}
+
+func (p *Palette) Paint(c Color) string {
+	return c.String()
+}
+
 var rat0, _ = big.ParseRat("0")
 var spaces = regexp.MustCompile(`  *`)
 var newline = regexp.MustCompile(`\n *`)
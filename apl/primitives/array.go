@@ -154,6 +154,10 @@ func array2(symbol string, fn func(*apl.Apl, apl.Value, apl.Value) (apl.Value, b
 			return apl.EmptyArray{}, nil
 		}
 
+		if v, ok := floatArray2(symbol, L, R); ok {
+			return v, nil
+		}
+
 		al, isLarray := L.(apl.Array)
 		ar, isRarray := R.(apl.Array)
 
@@ -0,0 +1,89 @@
+package primitives
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/operators"
+)
+
+// bitTests are evaluated with packed and unpacked boolean arrays.
+var bitTests = []string{
+	"B←(5000⍴1 0 0)=1",
+	"+/B",
+	"∧/B",
+	"∨/B",
+	"≠/B",
+	"∧/~B∧0",
+	"+/~B",
+	"+/B/⍳5000",
+	"+/⍸B",
+	"10↑B",
+	"¯7↑B",
+	"+/,⍉100 50⍴B",
+	"+⌿100 50⍴B",
+	"B[2]←1 ⋄ +/B",
+	"+/B∧⌽B",
+	"+/B≠1⌽B",
+	"(⍳5000)[⍸B]≡⍸B",
+}
+
+func evalBits(t testing.TB, threshold int) []string {
+	save := apl.BitThreshold
+	apl.BitThreshold = threshold
+	defer func() { apl.BitThreshold = save }()
+
+	var buf strings.Builder
+	a := apl.New(&buf)
+	numbers.Register(a)
+	Register(a)
+	operators.Register(a)
+	res := make([]string, len(bitTests))
+	for i, s := range bitTests {
+		buf.Reset()
+		if err := a.ParseAndEval(s); err != nil {
+			t.Fatalf("%s: %s", s, err)
+		}
+		res[i] = buf.String()
+	}
+	return res
+}
+
+func TestBitArray(t *testing.T) {
+	packed := evalBits(t, 64)
+	unpacked := evalBits(t, 1<<30)
+	for i := range packed {
+		if packed[i] != unpacked[i] {
+			t.Fatalf("%s: BitArray: %q, BoolArray: %q", bitTests[i], packed[i], unpacked[i])
+		}
+	}
+}
+
+func benchmarkReduceAnd(b *testing.B, threshold int) {
+	save := apl.BitThreshold
+	apl.BitThreshold = threshold
+	defer func() { apl.BitThreshold = save }()
+
+	a := apl.New(nil)
+	numbers.Register(a)
+	Register(a)
+	operators.Register(a)
+	if err := a.ParseAndEval("B←(1000000⍴1)=1"); err != nil {
+		b.Fatal(err)
+	}
+	p, err := a.Parse("∧/B")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := a.EvalProgram(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReduceAndBitArray(b *testing.B)  { benchmarkReduceAnd(b, 64) }
+func BenchmarkReduceAndBoolArray(b *testing.B) { benchmarkReduceAnd(b, 1<<30) }
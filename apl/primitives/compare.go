@@ -63,11 +63,7 @@ func compare(symbol string) func(*apl.Apl, apl.Value, apl.Value) (apl.Value, boo
 		case "<":
 			return less(L, R)
 		case ">":
-			eq, ls, ok := equalless(L, R)
-			if ok == false {
-				return nil, false
-			}
-			return apl.Bool(!eq && !ls), true
+			return less(R, L)
 		case "≠":
 			eq, ok := equals(L, R)
 			if ok == false {
@@ -81,11 +77,11 @@ func compare(symbol string) func(*apl.Apl, apl.Value, apl.Value) (apl.Value, boo
 			}
 			return apl.Bool(eq || ls), true
 		case "≥":
-			eq, ls, ok := equalless(L, R)
+			eq, gt, ok := equalless(R, L)
 			if ok == false {
 				return nil, false
 			}
-			return apl.Bool(eq || !ls), true
+			return apl.Bool(eq || gt), true
 		}
 		return nil, false
 	}
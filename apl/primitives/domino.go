@@ -40,10 +40,12 @@ func domino(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
 		f := array1("÷", div)
 		return f(a, nil, R)
 	}
-	if rs[0] != rs[1] {
-		return nil, fmt.Errorf("matrix inverse only works for a quadratic matrix")
+	if rs[0] < rs[1] {
+		return nil, fmt.Errorf("matrix inverse: matrix has more columns than rows")
 	}
 
+	// For more rows than columns, the result is the left inverse (A^H A)^-1 A^H,
+	// which is computed by the least squares solution of I⌹A.
 	n := rs[0]
 	I := apl.IntArray{Dims: []int{n, n}}
 	I.Ints = make([]int, n*n)
@@ -129,7 +131,7 @@ func domino2(a *apl.Apl, RHS, R apl.Value) (apl.Value, error) {
 	}
 
 	// LU Decomposition overwrites M and returns the permutation matrix.
-	P, err := lu(a, A)
+	P, err := LU(a, A)
 	if err != nil {
 		return nil, err
 	}
@@ -154,8 +156,13 @@ func domino2(a *apl.Apl, RHS, R apl.Value) (apl.Value, error) {
 	return a.UnifyArray(res), nil
 }
 
+// ErrSingular is returned by LU for a singular matrix.
+var ErrSingular = fmt.Errorf("matrix is singular")
+
 // LU decomposition.
-func lu(a *apl.Apl, A [][]apl.Value) ([]int, error) {
+// LU overwrites the square matrix A with L and U and returns the row permutation.
+// It works for all numeric towers and returns ErrSingular, if a pivot is 0.
+func LU(a *apl.Apl, A [][]apl.Value) ([]int, error) {
 	fabs := arith1("|", abs)
 	fmul := arith2("×", mul2)
 	fdiv := arith2("÷", div2)
@@ -191,7 +198,7 @@ func lu(a *apl.Apl, A [][]apl.Value) ([]int, error) {
 
 		// We do not compare against a tolerance, but against 0.
 		if isEqual(a, apl.Int(0), max) {
-			return nil, fmt.Errorf("matrix is singular")
+			return nil, ErrSingular
 		}
 
 		if imax != i {
@@ -472,12 +472,20 @@ type gcder interface {
 	Gcd(R apl.Value) (apl.Value, bool)
 }
 
+type lcmer interface {
+	Lcm(R apl.Value) (apl.Value, bool)
+}
+
 func lcm(a *apl.Apl, L, R apl.Value) (apl.Value, bool) {
 	// lcm(R, L) = abs(L times R) / gcd(L, R)
 	// If any of L or R is 0, return 0
+	// Types may compute it directly, e.g. rationals avoid the product.
 	if a.IsZero(L.(apl.Number)) || a.IsZero(R.(apl.Number)) {
 		return apl.Int(0), true
 	}
+	if l, ok := L.(lcmer); ok {
+		return l.Lcm(R)
+	}
 	p, ok := mul2(a, L, R)
 	if ok == false {
 		return nil, false
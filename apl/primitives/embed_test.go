@@ -0,0 +1,125 @@
+package primitives
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/operators"
+)
+
+func newEmbedded(w *strings.Builder) *apl.Apl {
+	a := apl.New(w)
+	numbers.Register(a)
+	Register(a)
+	operators.Register(a)
+	return a
+}
+
+func TestEvalString(t *testing.T) {
+	var stdout strings.Builder
+	a := newEmbedded(&stdout)
+
+	testCases := []struct {
+		in, exp string
+	}{
+		{"1+2", "3\n"},
+		{"X←⍳3 ⋄ X ⋄ 2×X", "1 2 3\n2 4 6\n"},
+		{"X←5", ""},
+		{"⎕←X ⋄ 2 2⍴X+⍳4", "5\n 6 7\n 8 9\n"},
+	}
+	for _, tc := range testCases {
+		got, err := a.EvalString(tc.in)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.in, err)
+		}
+		if got != tc.exp {
+			t.Fatalf("%s: expected %q, got %q", tc.in, tc.exp, got)
+		}
+	}
+
+	// Output written before an error is returned.
+	if got, err := a.EvalString("1 ⋄ 1 2+1 2 3"); err == nil || got != "1\n" {
+		t.Fatalf("expected output before error, got %q %v", got, err)
+	}
+
+	// The original writer is restored.
+	if err := a.ParseAndEval("7"); err != nil {
+		t.Fatal(err)
+	} else if s := stdout.String(); s != "7\n" {
+		t.Fatalf("stdout: expected %q, got %q", "7\n", s)
+	}
+}
+
+// EvalString does not change the output of forks, that run concurrently.
+func TestEvalStringFork(t *testing.T) {
+	var stdout strings.Builder
+	a := newEmbedded(&stdout)
+
+	f := a.Fork()
+	done := make(chan error)
+	go func() {
+		for i := 0; i < 100; i++ {
+			if err := f.ParseAndEval("1"); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	for i := 0; i < 100; i++ {
+		if got, err := a.EvalString("2"); err != nil {
+			t.Fatal(err)
+		} else if got != "2\n" {
+			t.Fatalf("expected %q, got %q", "2\n", got)
+		}
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	} else if s := stdout.String(); s != strings.Repeat("1\n", 100) {
+		t.Fatalf("stdout: expected the output of the fork only, got %q", s)
+	}
+}
+
+func TestEvalValue(t *testing.T) {
+	var stdout strings.Builder
+	a := newEmbedded(&stdout)
+
+	v, err := a.EvalValue("1 ⋄ 2+3")
+	if err != nil {
+		t.Fatal(err)
+	} else if n, ok := v.(apl.Int); ok == false || n != 5 {
+		t.Fatalf("expected apl.Int 5, got %T %v", v, v)
+	} else if s := stdout.String(); s != "1\n" {
+		t.Fatalf("only the last value should be returned, output is %q", s)
+	}
+
+	v, err = a.EvalValue("X←2 3⍴⍳6")
+	if err != nil {
+		t.Fatal(err)
+	} else if ar, ok := v.(apl.IntArray); ok == false {
+		t.Fatalf("expected apl.IntArray, got %T", v)
+	} else if s := ar.Shape(); len(s) != 2 || s[0] != 2 || s[1] != 3 || ar.Ints[5] != 6 {
+		t.Fatalf("unexpected result: %v %v", s, ar.Ints)
+	}
+
+	v, err = a.EvalValue(`(1;2 3;"abc";)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, ok := v.(apl.List)
+	if ok == false || len(l) != 3 {
+		t.Fatalf("expected a list of 3 values, got %T %v", v, v)
+	}
+	if ar, ok := l[1].(apl.IntArray); ok == false || ar.Size() != 2 {
+		t.Fatalf("list element 2: expected 2 ints, got %T", l[1])
+	}
+	if s, ok := l[2].(apl.String); ok == false || s != "abc" {
+		t.Fatalf("list element 3: expected string abc, got %T %v", l[2], l[2])
+	}
+
+	if _, err := a.EvalValue("1 2+1 2 3"); err == nil {
+		t.Fatal("expected error")
+	}
+}
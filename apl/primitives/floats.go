@@ -0,0 +1,87 @@
+package primitives
+
+import (
+	"math"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+)
+
+// floatArray2 is a fast path for elementwise arithmetic on float arrays.
+// It applies to + - × ⌊ ⌈, if at least one argument is a FloatArray and
+// the other is a FloatArray, an IntArray or a scalar Float or Int.
+// Int values are promoted to float.
+// L and R have been tested by arrays: they have the same shape or one is a scalar.
+func floatArray2(symbol string, L, R apl.Value) (apl.Value, bool) {
+	var f func(x, y float64) float64
+	switch symbol {
+	case "+":
+		f = func(x, y float64) float64 { return x + y }
+	case "-":
+		f = func(x, y float64) float64 { return x - y }
+	case "×":
+		f = func(x, y float64) float64 { return x * y }
+	case "⌊":
+		f = math.Min
+	case "⌈":
+		f = math.Max
+	default:
+		return nil, false
+	}
+	_, lf := L.(numbers.FloatArray)
+	_, rf := R.(numbers.FloatArray)
+	if lf == false && rf == false {
+		return nil, false
+	}
+	lv, ls, ok := floats(L)
+	if ok == false {
+		return nil, false
+	}
+	rv, rs, ok := floats(R)
+	if ok == false {
+		return nil, false
+	}
+
+	var res numbers.FloatArray
+	if lv != nil {
+		res = numbers.FloatArray{Dims: apl.CopyShape(L.(apl.Array)), Floats: make([]float64, len(lv))}
+	} else {
+		res = numbers.FloatArray{Dims: apl.CopyShape(R.(apl.Array)), Floats: make([]float64, len(rv))}
+	}
+	r := res.Floats
+	switch {
+	case lv != nil && rv != nil:
+		for i := range r {
+			r[i] = f(lv[i], rv[i])
+		}
+	case lv != nil:
+		for i := range r {
+			r[i] = f(lv[i], rs)
+		}
+	default:
+		for i := range r {
+			r[i] = f(ls, rv[i])
+		}
+	}
+	return res, true
+}
+
+// floats returns the values of a FloatArray or IntArray as a slice,
+// or a Float or Int scalar as a float64.
+func floats(v apl.Value) ([]float64, float64, bool) {
+	switch x := v.(type) {
+	case numbers.FloatArray:
+		return x.Floats, 0, true
+	case apl.IntArray:
+		f := make([]float64, len(x.Ints))
+		for i, n := range x.Ints {
+			f[i] = float64(n)
+		}
+		return f, 0, true
+	case numbers.Float:
+		return nil, float64(x), true
+	case apl.Int:
+		return nil, float64(x), true
+	}
+	return nil, 0, false
+}
@@ -0,0 +1,39 @@
+package primitives
+
+import (
+	"testing"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/operators"
+)
+
+// benchmarkExpr evaluates setup once and measures the evaluation of expr.
+func benchmarkExpr(b *testing.B, setup, expr string) {
+	a := apl.New(nil)
+	numbers.Register(a)
+	Register(a)
+	operators.Register(a)
+	if err := a.ParseAndEval(setup); err != nil {
+		b.Fatal(err)
+	}
+	p, err := a.Parse(expr)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := a.EvalProgram(p); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+const floatSetup = "X←0.5+⍳100000 ⋄ Y←1.5×⍳100000 ⋄ I←⍳100000"
+
+func BenchmarkFloatAdd(b *testing.B)       { benchmarkExpr(b, floatSetup, "X+Y") }
+func BenchmarkFloatAddInt(b *testing.B)    { benchmarkExpr(b, floatSetup, "X+I") }
+func BenchmarkFloatScalar(b *testing.B)    { benchmarkExpr(b, floatSetup, "2.5×X") }
+func BenchmarkFloatDivide(b *testing.B)    { benchmarkExpr(b, floatSetup, "X÷Y") }
+func BenchmarkFloatReduce(b *testing.B)    { benchmarkExpr(b, floatSetup, "+/X") }
+func BenchmarkFloatMaxReduce(b *testing.B) { benchmarkExpr(b, floatSetup, "⌈/X") }
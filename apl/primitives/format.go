@@ -16,7 +16,10 @@ func init() {
 		doc:    "format, convert to string",
 		Domain: Monadic(nil),
 		fn: func(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
-			return apl.String(R.String(a.Format)), nil
+			// Digits are not grouped, such that ⍎ can parse the result.
+			f := a.Format
+			f.Group = ""
+			return apl.String(R.String(f)), nil
 		},
 	})
 	register(primitive{
@@ -159,6 +162,7 @@ func execute(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
 
 // ParseData parses data from strings that has been written with ¯1⍕V.
 // L may be "A", "D" or "T" for array, dict or table.
+// If L is "json", R is parsed as json.
 // If L is a value of type array, dict or table it is used as a prototype with stricter requirements.
 func parseData(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 	var p apl.Value
@@ -183,6 +187,8 @@ func parseData(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 		return a.ParseDict(p, string(rs))
 	case "T":
 		return a.ParseTable(p, string(rs))
+	case "json":
+		return a.ParseJSON(string(rs))
 	}
 	return nil, fmt.Errorf("parse data: left argument is an unknown type: %s", ls)
 }
@@ -165,7 +165,7 @@ func objSelection(a *apl.Apl, L, R apl.Value) (apl.IntArray, error) {
 // to distinguish them from vector indexes (multiple keys at the same level).
 func objDepthSelection(a *apl.Apl, o apl.Object, spec apl.IdxSpec, ia apl.IntArray) (apl.IntArray, error) {
 	key := spec[0]
-	val := o.At(key)
+	val := apl.Unfreeze(o.At(key)) // Assignment into a frozen value fails later.
 	if val == nil {
 		return ia, fmt.Errorf("obj depth sel: key does not exist: %v", key)
 	}
@@ -209,6 +209,8 @@ func objDepthSelection(a *apl.Apl, o apl.Object, spec apl.IdxSpec, ia apl.IntArr
 		}
 		add(idx)
 		return ia, nil
+	} else if _, ok := val.(apl.Array); ok == false {
+		return ia, fmt.Errorf("obj depth sel: cannot index into %T", val)
 	} else {
 		idx, err := indexSelection(a, spec[1:], val)
 		if err != nil {
@@ -345,6 +347,13 @@ func objIndex(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 	// If the spec is a single value, return the value for the key.
 	sv, ok := spec[0].(apl.Array)
 	if ok == false {
+		if r, ok := obj.(apl.Receiver); ok {
+			if v, ok := r.Receive(spec[0]); ok && v == nil {
+				return nil, fmt.Errorf("cannot receive from channel")
+			} else if ok {
+				return v, nil
+			}
+		}
 		v := obj.At(spec[0])
 		if v == nil {
 			return nil, fmt.Errorf("key does not exist")
@@ -373,7 +382,7 @@ func objIndex(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 
 func objDepthIndex(a *apl.Apl, obj apl.Object, spec apl.IdxSpec) (apl.Value, error) {
 	key := spec[0]
-	v := obj.At(key)
+	v := apl.Unfreeze(obj.At(key))
 	if v == nil {
 		return nil, fmt.Errorf("key does not exist: %q", key.String(apl.Format{}))
 	}
@@ -409,7 +418,7 @@ func listIndex(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 		if i == len(idx)-1 {
 			return v.Copy(), nil
 		} else {
-			lst = v.(apl.List)
+			lst = apl.Unfreeze(v).(apl.List)
 		}
 	}
 	return lst, nil
@@ -456,7 +465,7 @@ func listSelection(a *apl.Apl, L, R apl.Value) (apl.IntArray, error) {
 		idx[i] = k
 		v := lst[k]
 		if i < len(idx)-1 {
-			if l, ok := v.(apl.List); ok {
+			if l, ok := apl.Unfreeze(v).(apl.List); ok {
 				lst = l
 			} else {
 				return ai, fmt.Errorf("list index is too deep")
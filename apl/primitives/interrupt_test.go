@@ -0,0 +1,105 @@
+package primitives
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/operators"
+)
+
+func TestInterrupt(t *testing.T) {
+	testCases := []string{
+		"{⍵+1}⍣1000000000⊢0",
+		"(+∘1)⍣1000000000⊢0",
+		"f←{f ⍵+1} ⋄ f 0",
+		"f←{0=⍵:0 ⋄ 1+f ⍵-1} ⋄ f 1000000000",
+		"f←{f ⍵+1}⎊{0} ⋄ f 0",
+	}
+	for _, prog := range testCases {
+		a := apl.New(ioutil.Discard)
+		numbers.Register(a)
+		Register(a)
+		operators.Register(a)
+
+		done := make(chan error)
+		go func() {
+			done <- a.ParseAndEval(prog)
+		}()
+		time.Sleep(20 * time.Millisecond)
+		a.Interrupt()
+		select {
+		case err := <-done:
+			if err != apl.ErrInterrupted {
+				t.Fatalf("%s: expected %v, got %v", prog, apl.ErrInterrupted, err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("%s: evaluation was not interrupted", prog)
+		}
+
+		// The interrupt is consumed and has no effect on the next evaluation.
+		if err := a.ParseAndEval("X←+/⍳10"); err != nil {
+			t.Fatalf("%s: evaluation after interrupt: %s", prog, err)
+		}
+	}
+}
+
+// An interrupt before the evaluation starts is not lost.
+// It is kept by nested evaluations and consumed by the top-level one.
+func TestInterruptPending(t *testing.T) {
+	a := apl.New(ioutil.Discard)
+	numbers.Register(a)
+	Register(a)
+	operators.Register(a)
+
+	a.Interrupt()
+	if err := a.ParseAndEval("X←+/⍳10"); err != apl.ErrInterrupted {
+		t.Fatalf("expected %v, got %v", apl.ErrInterrupted, err)
+	}
+	if err := a.ParseAndEval("X←+/⍳10"); err != nil {
+		t.Fatalf("interrupt is not consumed: %s", err)
+	}
+
+	a.RegisterPackage("t", map[string]apl.Value{
+		"e": apl.ToFunction(func(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
+			a.Interrupt()
+			return a.EvalValue(string(R.(apl.String)))
+		}),
+	})
+	if err := a.ParseAndEval(`t→e "1" ⋄ {⍵+1}⍣1000000000⊢0`); err != apl.ErrInterrupted {
+		t.Fatalf("nested: expected %v, got %v", apl.ErrInterrupted, err)
+	}
+	if err := a.ParseAndEval("X←+/⍳10"); err != nil {
+		t.Fatalf("nested: interrupt is not consumed: %s", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	a := apl.New(ioutil.Discard)
+	numbers.Register(a)
+	Register(a)
+	operators.Register(a)
+
+	p, err := a.Parse("f←{f ⍵+1} ⋄ f 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := a.EvalTimeout(p, 20*time.Millisecond); err != apl.ErrTimeout {
+		t.Fatalf("expected %v, got %v", apl.ErrTimeout, err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Fatalf("timeout returned after %v", d)
+	}
+
+	// A short program is not affected.
+	p, err = a.Parse("X←+/⍳10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.EvalTimeout(p, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -33,6 +33,12 @@ func init() {
 		Domain: Dyadic(nil),
 		fn:     notmatch,
 	})
+	register(primitive{
+		symbol: "≅",
+		doc:    "match within comparison tolerance ⎕CT",
+		Domain: Dyadic(nil),
+		fn:     tolerantMatch,
+	})
 }
 
 // depth reports the level of nesting.
@@ -67,7 +73,57 @@ func tally(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
 	return apl.Int(shape[0]), nil
 }
 
+// match compares L and R recursively.
+// Arrays must have the same shape and their elements must match.
+// Nested arrays (lists) are compared element by element at each level.
+// Numbers at the leaves are converted to the same type before comparison.
 func match(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return deepMatch(a, L, R, isEqual)
+}
+
+// tolerantMatch is match, but numbers at the leaves are equal,
+// if they differ relatively by not more than the comparison tolerance:
+//
+//	|L-R| ≤ ⎕CT × (|L|⌈|R|)
+//
+// Other values are compared exactly.
+func tolerantMatch(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	ct := a.Tolerance()
+	return deepMatch(a, L, R, func(a *apl.Apl, x, y apl.Value) bool {
+		if isEqual(a, x, y) {
+			return true
+		} else if ct == nil {
+			return false
+		}
+		return isClose(a, x, y, ct)
+	})
+}
+
+// isClose tests if the numbers x and y are equal within the tolerance ct.
+// Infinities and NaN are not close to any other number.
+func isClose(a *apl.Apl, x, y, ct apl.Value) bool {
+	var err error
+	call := func(f string, L, R apl.Value) apl.Value {
+		if err != nil {
+			return nil
+		}
+		var v apl.Value
+		v, err = apl.Primitive(f).Call(a, L, R)
+		return v
+	}
+	finite := func(v apl.Value) bool {
+		return call("=", call("-", v, v), apl.Int(0)) == apl.Bool(true)
+	}
+	if finite(x) == false || finite(y) == false {
+		return false
+	}
+	d := call("|", nil, call("-", x, y))
+	m := call("⌈", call("|", nil, x), call("|", nil, y))
+	le := call("≤", d, call("×", ct, m))
+	return err == nil && le == apl.Bool(true)
+}
+
+func deepMatch(a *apl.Apl, L, R apl.Value, eq func(*apl.Apl, apl.Value, apl.Value) bool) (apl.Value, error) {
 	al, isal := L.(apl.Array)
 	ar, isar := R.(apl.Array)
 	if isal != isar {
@@ -75,7 +131,7 @@ func match(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 	}
 	if isal == false {
 		// Compare scalars, convert numbers to the same type.
-		return apl.Bool(isEqual(a, L, R)), nil
+		return apl.Bool(eq(a, L, R)), nil
 	} else {
 		sl := al.Shape()
 		sr := ar.Shape()
@@ -94,9 +150,8 @@ func match(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 				return apl.Bool(false), nil
 			}
 		}
-		feq := arith2("=", compare("="))
 		for i := 0; i < ar.Size(); i++ {
-			if iseq, err := feq(a, ar.At(i), al.At(i)); err != nil {
+			if iseq, err := deepMatch(a, al.At(i), ar.At(i), eq); err != nil {
 				return nil, err
 			} else if iseq.(apl.Bool) == false {
 				return apl.Bool(false), nil
@@ -0,0 +1,30 @@
+package primitives
+
+import (
+	"testing"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/operators"
+)
+
+// benchmarkFib parses the program in each iteration,
+// such that the memoized version starts with an empty cache.
+func benchmarkFib(b *testing.B, prog string) {
+	a := apl.New(nil)
+	numbers.Register(a)
+	Register(a)
+	operators.Register(a)
+	for i := 0; i < b.N; i++ {
+		if err := a.ParseAndEval(prog); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFib(b *testing.B) {
+	benchmarkFib(b, "fib←{⍵<2:⍵ ⋄ (fib ⍵-1)+fib ⍵-2} ⋄ X←fib 18")
+}
+func BenchmarkFibMemo(b *testing.B) {
+	benchmarkFib(b, "fib←{⍵<2:⍵ ⋄ (fib ⍵-1)+fib ⍵-2}⍥ ⋄ X←fib 18")
+}
@@ -0,0 +1,46 @@
+package primitives
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+	. "github.com/ktye/iv/apl/domain"
+)
+
+func init() {
+	register(primitive{
+		symbol: "↯",
+		doc:    "signal, raise an error",
+		Domain: Monadic(nil),
+		fn:     signal,
+	})
+	register(primitive{
+		symbol: "↯",
+		doc:    "signal, raise an error with code L",
+		Domain: Dyadic(Split(ToIndex(nil), nil)),
+		fn:     signal,
+	})
+}
+
+// signal returns an error.
+// R is an error value or a string or character vector containing the message.
+// An error value is signaled unchanged.
+// L is an optional error code.
+//	↯"bad input"
+//	↯'bad input'
+//	11 ↯"domain error"
+func signal(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	e, ok := R.(apl.Error)
+	if ok == false {
+		s, err := apl.JoinString(R)
+		if err != nil {
+			return nil, fmt.Errorf("signal: R must be a string or an error value: %T", R)
+		}
+		e = apl.Error{E: errors.New(s)}
+	}
+	if L != nil {
+		e.Code = int(L.(apl.Int))
+	}
+	return nil, e
+}
@@ -0,0 +1,54 @@
+package primitives
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/operators"
+)
+
+// flushRecorder is a buffered writer that records the order of events.
+type flushRecorder struct {
+	buf    strings.Builder
+	events []string
+}
+
+func (w *flushRecorder) Write(p []byte) (int, error) { return w.buf.Write(p) }
+func (w *flushRecorder) Flush() error {
+	w.events = append(w.events, strings.TrimSpace(w.buf.String()))
+	w.buf.Reset()
+	return nil
+}
+
+func TestStreamOutput(t *testing.T) {
+	testCases := []struct {
+		in     string
+		events []string
+	}{
+		{"1⋄2⋄3", []string{"1", "2", "3"}},
+		{"1⋄t→mark 0⋄2", []string{"1", "mark", "0", "2"}},
+		{"⎕←1⋄t→mark 0⋄X←2", []string{"1", "mark", "0"}},
+		{"{⎕←⍵⋄t→mark ⍵}¨1 2", []string{"1", "mark", "2", "mark", "1 2"}},
+	}
+	for _, tc := range testCases {
+		w := &flushRecorder{}
+		a := apl.New(w)
+		numbers.Register(a)
+		Register(a)
+		operators.Register(a)
+		a.RegisterPackage("t", map[string]apl.Value{
+			"mark": apl.ToFunction(func(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+				w.events = append(w.events, "mark")
+				return R, nil
+			}),
+		})
+		if err := a.ParseAndEval(tc.in); err != nil {
+			t.Fatalf("%s: %s", tc.in, err)
+		}
+		if got, exp := strings.Join(w.events, "|"), strings.Join(tc.events, "|"); got != exp {
+			t.Fatalf("%s: expected %s, got %s", tc.in, exp, got)
+		}
+	}
+}
@@ -267,12 +267,17 @@ func cut(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 	return res, nil
 }
 
+// takeChannel1 reads one value from channel R.
+// An error value that was sent over the channel is returned as an error.
 func takeChannel1(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
 	c := R.(apl.Channel)
 	v, ok := <-c[0]
 	if ok == false {
 		return nil, fmt.Errorf("channel is closed")
 	}
+	if e, ok := v.(apl.Error); ok && e.E != nil {
+		return nil, e.E
+	}
 	return v, nil
 }
 
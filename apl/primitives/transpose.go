@@ -58,12 +58,7 @@ func transpose(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 		return nil, err
 	}
 
-	ar := R.(apl.Array)
-	res := apl.MakeArray(ar, shape)
-	for i, k := range idx {
-		res.Set(i, ar.At(k).Copy())
-	}
-	return res, nil
+	return apl.NewView(R.(apl.Array), shape, idx, nil), nil
 }
 
 func transposeIndexes(a *apl.Apl, L, R apl.Value) ([]int, []int, error) {
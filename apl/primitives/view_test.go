@@ -0,0 +1,74 @@
+package primitives
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/numbers"
+	"github.com/ktye/iv/apl/operators"
+)
+
+// viewTests are evaluated with and without lazy views.
+var viewTests = []string{
+	"M←100 50⍴⍳5000",
+	"+/,⍉M",
+	"+/,2↓M",
+	"+/,¯3↓⍉M",
+	"(⍉⍉M)≡M",
+	"⍴5↑⍉3↓M",
+	"+/,120 60↑M",
+	"+/,¯120 ¯60↑M",
+	"+/,120 60↑⍉M",
+	"+/,⍉120 60↑M",
+	"+/,¯120 ¯60↑80 70↑M",
+	"+/,1 2↓2 1 3⍉2 5 500⍴M",
+	"(2↓⍉M)[1;1 2 3]",
+	"A←⍉M ⋄ A[1;2]←0 ⋄ M[2;1] ⋄ A[1;2]",
+	"A←2↓M ⋄ A[1;]←0 ⋄ +/M[3;] ⋄ +/A[1;]",
+	"(2 3↑M)←0 ⋄ +/,M",
+	"S←⍉100 50⍴'ab' ⋄ S[1;1 2]",
+}
+
+func evalViews(t testing.TB, threshold int) []string {
+	save := apl.ViewThreshold
+	apl.ViewThreshold = threshold
+	defer func() { apl.ViewThreshold = save }()
+
+	var buf strings.Builder
+	a := apl.New(&buf)
+	numbers.Register(a)
+	Register(a)
+	operators.Register(a)
+	res := make([]string, len(viewTests))
+	for i, s := range viewTests {
+		buf.Reset()
+		if err := a.ParseAndEval(s); err != nil {
+			t.Fatalf("%s: %s", s, err)
+		}
+		res[i] = buf.String()
+	}
+	return res
+}
+
+func TestView(t *testing.T) {
+	lazy := evalViews(t, 0)
+	eager := evalViews(t, 1<<30)
+	for i := range lazy {
+		if lazy[i] != eager[i] {
+			t.Fatalf("%s: view: %q, copy: %q", viewTests[i], lazy[i], eager[i])
+		}
+	}
+}
+
+func benchmarkView(b *testing.B, threshold int, expr string) {
+	save := apl.ViewThreshold
+	apl.ViewThreshold = threshold
+	defer func() { apl.ViewThreshold = save }()
+	benchmarkExpr(b, "M←1000 1000⍴⍳1000000", expr)
+}
+
+func BenchmarkTransposeView(b *testing.B) { benchmarkView(b, 0, "⍉⍉⍉⍉M") }
+func BenchmarkTransposeCopy(b *testing.B) { benchmarkView(b, 1<<30, "⍉⍉⍉⍉M") }
+func BenchmarkDropView(b *testing.B)      { benchmarkView(b, 0, "1↓1↓⍉1↓1↓M") }
+func BenchmarkDropCopy(b *testing.B)      { benchmarkView(b, 1<<30, "1↓1↓⍉1↓1↓M") }
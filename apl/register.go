@@ -38,8 +38,9 @@ func (a *Apl) registerSymbol(s string) {
 }
 
 // RegisterPackage adds an external package to apl.
+// Variables of a registered package are read-only.
 func (a *Apl) RegisterPackage(name string, m map[string]Value) {
-	a.pkg[name] = &env{parent: nil, vars: m}
+	a.pkg[name] = &env{parent: nil, vars: m, readonly: true}
 }
 
 // Doc writes the documentation of all registered primitives and operators to the writer.
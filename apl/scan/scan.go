@@ -47,6 +47,7 @@ type Scanner struct {
 	commands map[string]Command
 	pos      int
 	width    int
+	decimal  rune
 }
 
 // SetSymbols initializes the Scanner to recognize the given APL symbols.
@@ -55,6 +56,13 @@ func (s *Scanner) SetSymbols(symbols map[rune]string) {
 	s.symbols = symbols
 }
 
+// SetDecimal sets the decimal separator for numbers.
+// The period is always accepted. If r is a comma, 3,14 is also scanned as 3.14.
+// A comma followed by a space or another non-digit is still a separate token.
+func (s *Scanner) SetDecimal(r rune) {
+	s.decimal = r
+}
+
 // AddCommands sets token rewrite commands.
 func (s *Scanner) AddCommands(commands map[string]Command) {
 	if s.commands == nil {
@@ -218,8 +226,8 @@ func (s *Scanner) nextToken() (Token, error) {
 			return s.scanString(r)
 		}
 
-		// A number starts with [0-9] or "." or "¯".
-		if (r >= '0' && r <= '9') || r == '.' || r == '¯' {
+		// A number starts with [0-9] or "." or "¯" or "∞".
+		if (r >= '0' && r <= '9') || r == '.' || r == '¯' || r == '∞' {
 			if r == '.' {
 				// If it start with . a digit must follow,
 				// otherwise it could be the dot operator.
@@ -285,6 +293,22 @@ func (s *Scanner) scanNumber() (Token, error) {
 	if err != nil {
 		return Token{}, err
 	}
+	// A decimal comma is accepted, if the number or the imaginary part
+	// has no decimal point yet. Otherwise the comma is catenation.
+	for s.decimal == ',' && s.peek() == ',' {
+		if strings.Contains(num[strings.LastIndex(num, "J")+1:], ".") {
+			break
+		}
+		if n := s.input[s.pos+1:]; len(n) == 0 || n[0] < '0' || n[0] > '9' {
+			break
+		}
+		s.nextRune()
+		frac, err := ScanNumber(s)
+		if err != nil {
+			return Token{}, err
+		}
+		num += "." + frac
+	}
 	return Token{T: Number, S: num}, nil
 }
 
@@ -306,7 +330,7 @@ func ScanNumber(s io.RuneScanner) (string, error) {
 			buf.WriteRune(r)
 		} else if r == '.' {
 			buf.WriteRune(r)
-		} else if r == '¯' {
+		} else if r == '¯' || r == '∞' {
 			buf.WriteRune(r)
 		} else {
 			s.UnreadRune()
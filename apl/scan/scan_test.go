@@ -32,6 +32,7 @@ func TestScan(t *testing.T) {
 		}},
 		{"¯1.0E¯6a123.8", []Token{Token{T: Number, S: "¯1.0E¯6a123.8"}}},
 		{"¯8", []Token{Token{T: Number, S: "¯8"}}},
+		{"∞ ¯∞", []Token{Token{T: Number, S: "∞"}, Token{T: Number, S: "¯∞"}}},
 		{`"a⍝b"+8.2⍝comment`, []Token{
 			Token{T: String, S: `a⍝b`},
 			Token{T: Symbol, S: "+"},
@@ -94,6 +95,40 @@ func TestScan(t *testing.T) {
 	}
 }
 
+func TestScanDecimal(t *testing.T) {
+	symbols := map[rune]string{',': ","}
+	testCases := []struct {
+		input string
+		exp   []string
+	}{
+		{"3,14", []string{"3.14"}},
+		{"3.14", []string{"3.14"}},
+		{"¯1,5E¯3", []string{"¯1.5E¯3"}},
+		{"1,5J2,5", []string{"1.5J2.5"}},
+		{"1 ,2", []string{"1", ",", "2"}},
+		{"1, 2", []string{"1", ",", "2"}},
+		{"1,", []string{"1", ","}},
+	}
+
+	var scn Scanner
+	scn.SetSymbols(symbols)
+	scn.SetDecimal(',')
+	for _, tc := range testCases {
+		got, err := scn.Scan(tc.input)
+		if err != nil {
+			t.Fatalf("%q: %s", tc.input, err)
+		}
+		if len(got) != len(tc.exp) {
+			t.Fatalf("%q: got %d Tokens, expected %d", tc.input, len(got), len(tc.exp))
+		}
+		for i, e := range tc.exp {
+			if got[i].S != e {
+				t.Fatalf("%q: got %+v, expected %q", tc.input, got[i], e)
+			}
+		}
+	}
+}
+
 func TestScanString(t *testing.T) {
 	testCases := [][2]string{
 		// Double quoted strings with backslash escapes.
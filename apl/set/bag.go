@@ -0,0 +1,123 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// Bags (multisets) are vectors, where duplicate elements count.
+// In contrast to the set functions, the results keep the order of the input.
+// An element that is present m times in L and n times in R, is present:
+//	max(m, n) times in L set→bagunion R
+//	min(m, n) times in L set→baginter R
+//	max(0, m-n) times in L set→bagdiff R
+// Elements of L come first and are removed from the front, as with progressive index-of.
+
+func bagunion(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return bag(a, "bagunion", L, R, func(l, r []apl.Value, lid, rid []int) []apl.Value {
+		v := append([]apl.Value{}, l...)
+		count := make(map[int]int)
+		for _, id := range lid {
+			count[id]++
+		}
+		for i, id := range rid {
+			if count[id] > 0 {
+				count[id]--
+			} else {
+				v = append(v, r[i])
+			}
+		}
+		return v
+	})
+}
+
+func baginter(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return bag(a, "baginter", L, R, func(l, r []apl.Value, lid, rid []int) []apl.Value {
+		var v []apl.Value
+		count := make(map[int]int)
+		for _, id := range rid {
+			count[id]++
+		}
+		for i, id := range lid {
+			if count[id] > 0 {
+				count[id]--
+				v = append(v, l[i])
+			}
+		}
+		return v
+	})
+}
+
+func bagdiff(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return bag(a, "bagdiff", L, R, func(l, r []apl.Value, lid, rid []int) []apl.Value {
+		var v []apl.Value
+		count := make(map[int]int)
+		for _, id := range rid {
+			count[id]++
+		}
+		for i, id := range lid {
+			if count[id] > 0 {
+				count[id]--
+			} else {
+				v = append(v, l[i])
+			}
+		}
+		return v
+	})
+}
+
+// bag calls f with the elements of the vectors L and R and their ids.
+// Equal elements have the same id, which is computed by V⍳V for V←L,R.
+func bag(a *apl.Apl, name string, L, R apl.Value, f func(l, r []apl.Value, lid, rid []int) []apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("%s: cannot be called monadically", name)
+	}
+	l, err := vector(a, L)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	r, err := vector(a, R)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	all := append(append([]apl.Value{}, l...), r...)
+	ids := make([]int, len(all))
+	if len(all) > 0 {
+		V := apl.MixedArray{Dims: []int{len(all)}, Values: all}
+		x, err := apl.Primitive("⍳").Call(a, V, V)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+		ia, ok := x.(apl.IntArray)
+		if ok == false {
+			return nil, fmt.Errorf("%s: index-of returned %T", name, x)
+		}
+		copy(ids, ia.Ints)
+	}
+
+	v := f(l, r, ids[:len(l)], ids[len(l):])
+	if len(v) == 0 {
+		return apl.EmptyArray{}, nil
+	}
+	for i := range v {
+		v[i] = v[i].Copy()
+	}
+	return a.UnifyArray(apl.MixedArray{Dims: []int{len(v)}, Values: v}), nil
+}
+
+// vector returns the elements of a vector or a scalar.
+func vector(a *apl.Apl, V apl.Value) ([]apl.Value, error) {
+	ar, ok := V.(apl.Array)
+	if ok == false {
+		return []apl.Value{V}, nil
+	}
+	if n := len(ar.Shape()); n > 1 {
+		return nil, fmt.Errorf("argument must be a vector: rank %d", n)
+	}
+	v := make([]apl.Value, ar.Size())
+	for i := range v {
+		v[i] = ar.At(i)
+	}
+	return v, nil
+}
@@ -0,0 +1,38 @@
+// Package set provides set operations with canonical results.
+//
+// The primitives ∪ and ~ return their results in the order of the input.
+// The functions of this package return the unique elements in ascending order,
+// such that equal sets compare equal with ≡, regardless of the input order.
+//
+//	set→unique R       unique elements of R
+//	L set→union R      elements in L or R
+//	L set→inter R      elements in L and R
+//	L set→diff R       elements in L but not in R
+//
+// Bag (multiset) variants count duplicates and keep the input order:
+//
+//	L set→bagunion R   1 1 2 set→bagunion 1 2 2 3 is 1 1 2 2 3
+//	L set→baginter R   1 1 2 set→baginter 1 2 2 3 is 1 2
+//	L set→bagdiff R    1 1 2 set→bagdiff 1 2 2 3 is 1
+package set
+
+import (
+	"github.com/ktye/iv/apl"
+)
+
+// Register adds the set package to the interpreter.
+func Register(a *apl.Apl, name string) {
+	if name == "" {
+		name = "set"
+	}
+	pkg := map[string]apl.Value{
+		"unique":   apl.ToFunction(unique),
+		"union":    apl.ToFunction(union),
+		"inter":    apl.ToFunction(inter),
+		"diff":     apl.ToFunction(diff),
+		"bagunion": apl.ToFunction(bagunion),
+		"baginter": apl.ToFunction(baginter),
+		"bagdiff":  apl.ToFunction(bagdiff),
+	}
+	a.RegisterPackage(name, pkg)
+}
@@ -0,0 +1,69 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+func unique(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("unique: cannot be called dyadically")
+	}
+	return sorted(a, "unique", nil, R)
+}
+
+func union(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("union: cannot be called monadically")
+	}
+	return sorted(a, "union", L, R)
+}
+
+// inter is computed as L~L~R.
+func inter(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("inter: cannot be called monadically")
+	}
+	d, err := apl.Primitive("~").Call(a, L, R)
+	if err != nil {
+		return nil, fmt.Errorf("inter: %s", err)
+	}
+	return diff(a, L, d)
+}
+
+func diff(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("diff: cannot be called monadically")
+	}
+	d, err := apl.Primitive("~").Call(a, L, R)
+	if err != nil {
+		return nil, fmt.Errorf("diff: %s", err)
+	}
+	return sorted(a, "diff", nil, d)
+}
+
+// sorted applies ∪ monadically or dyadically and sorts the result in ascending order.
+func sorted(a *apl.Apl, name string, L, R apl.Value) (apl.Value, error) {
+	u, err := apl.Primitive("∪").Call(a, L, R)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	ar, ok := u.(apl.Array)
+	if ok == false || ar.Size() < 2 {
+		return u, nil
+	}
+	g, err := apl.Primitive("⍋").Call(a, nil, u)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	idx, ok := g.(apl.IntArray)
+	if ok == false {
+		return nil, fmt.Errorf("%s: grade returned %T", name, g)
+	}
+	values := make([]apl.Value, len(idx.Ints))
+	for i, k := range idx.Ints {
+		values[i] = ar.At(k - a.Origin)
+	}
+	return a.UnifyArray(apl.MixedArray{Dims: []int{len(values)}, Values: values}), nil
+}
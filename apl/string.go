@@ -5,8 +5,16 @@ import (
 	"io"
 	"io/ioutil"
 	"reflect"
+	"strings"
 )
 
+// String is a go string and a scalar value.
+//
+// Character vectors such as 'alpha' are arrays of single rune Strings.
+// APL primitives like ≢, ⌽ or indexing work on these runes, not on bytes:
+//	≢'süß'    3
+// The strings package converts between strings and their UTF-8 bytes,
+// e.g. s→bytes "süß" has 5 elements.
 type String string
 
 // String prints a string.
@@ -52,6 +60,33 @@ func (s String) Export() reflect.Value {
 	return reflect.ValueOf(string(s))
 }
 
+// JoinString returns the go string of a string scalar or a character vector.
+// An empty array is the empty string.
+func JoinString(v Value) (string, error) {
+	if s, ok := v.(String); ok {
+		return string(s), nil
+	}
+	ar, ok := v.(Array)
+	if ok == false {
+		return "", fmt.Errorf("argument must be a string: %T", v)
+	}
+	if _, ok := ar.(EmptyArray); ok {
+		return "", nil
+	}
+	if s := ar.Shape(); len(s) != 1 {
+		return "", fmt.Errorf("argument must be a character vector")
+	}
+	var b strings.Builder
+	for i := 0; i < ar.Size(); i++ {
+		s, ok := ar.At(i).(String)
+		if ok == false {
+			return "", fmt.Errorf("argument must be a character vector: %T", ar.At(i))
+		}
+		b.WriteString(string(s))
+	}
+	return b.String(), nil
+}
+
 // StringArray is a uniform array of strings.
 type StringArray struct {
 	Dims    []int
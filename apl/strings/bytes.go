@@ -0,0 +1,53 @@
+package strings
+
+import (
+	"fmt"
+
+	"github.com/ktye/iv/apl"
+)
+
+// toBytes returns the UTF-8 encoding of a string or a rune vector as an integer vector.
+func toBytes(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("bytes: cannot be called dyadically")
+	}
+	s, err := join(R)
+	if err != nil {
+		return nil, fmt.Errorf("bytes: R must be a string or a rune vector")
+	}
+	if len(s) == 0 {
+		return apl.EmptyArray{}, nil
+	}
+	v := apl.IntArray{Dims: []int{len(s)}, Ints: make([]int, len(s))}
+	for i := range v.Ints {
+		v.Ints[i] = int(s[i])
+	}
+	return v, nil
+}
+
+// fromBytes converts an integer vector of bytes to a string.
+// The bytes are not required to be valid UTF-8.
+func fromBytes(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L != nil {
+		return nil, fmt.Errorf("frombytes: cannot be called dyadically")
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		ar = apl.MixedArray{Dims: []int{1}, Values: []apl.Value{R}}
+	} else if n := len(ar.Shape()); n > 1 {
+		return nil, fmt.Errorf("frombytes: R must be a vector: rank %d", n)
+	}
+	b := make([]byte, ar.Size())
+	for i := range b {
+		n, ok := ar.At(i).(apl.Number)
+		if ok == false {
+			return nil, fmt.Errorf("frombytes: R must contain bytes: %T", ar.At(i))
+		}
+		c, ok := n.ToIndex()
+		if ok == false || c < 0 || c > 255 {
+			return nil, fmt.Errorf("frombytes: value is not a byte: %s", ar.At(i).String(a.Format))
+		}
+		b[i] = byte(c)
+	}
+	return apl.String(b), nil
+}
@@ -0,0 +1,18 @@
+package strings
+
+import "github.com/ktye/iv/apl"
+
+// Character constants are registered as rune vectors.
+// They complement the system constants ⎕A and ⎕D:
+//	s→LOWER    abcdefghijklmnopqrstuvwxyz
+//	s→ALNUM    ⎕A,s→LOWER,⎕D
+const lower = "abcdefghijklmnopqrstuvwxyz"
+
+// alnum catenates ⎕A, lower and ⎕D from the interpreter's system constants.
+func alnum(a *apl.Apl) apl.StringArray {
+	var v []string
+	for _, s := range []apl.Value{a.Lookup("⎕A"), runes(lower), a.Lookup("⎕D")} {
+		v = append(v, s.(apl.StringArray).Strings...)
+	}
+	return apl.StringArray{Dims: []int{len(v)}, Strings: v}
+}
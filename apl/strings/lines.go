@@ -0,0 +1,40 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ktye/iv/apl"
+)
+
+// lines splits the string R at line endings, which may be "\n" or "\r\n".
+// The result is a vector of strings without the line endings.
+// A trailing newline does not start an empty last line, unless L is 1:
+//	s→lines "a\r\nb\n"      2 lines: a b
+//	1 s→lines "a\r\nb\n"    3 lines: a b and an empty line
+func lines(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	keep := false
+	if L != nil {
+		n, ok := L.(apl.Number)
+		if ok == false {
+			return nil, fmt.Errorf("lines: L must be 0 or 1: %T", L)
+		}
+		i, ok := n.ToIndex()
+		if ok == false || (i != 0 && i != 1) {
+			return nil, fmt.Errorf("lines: L must be 0 or 1: %s", L.String(a.Format))
+		}
+		keep = i == 1
+	}
+	s, ok := R.(apl.String)
+	if ok == false {
+		return nil, fmt.Errorf("lines: R must be a string: %T", R)
+	}
+	v := strings.Split(string(s), "\n")
+	if keep == false && len(v) > 1 && v[len(v)-1] == "" {
+		v = v[:len(v)-1]
+	}
+	for i := range v {
+		v[i] = strings.TrimSuffix(v[i], "\r")
+	}
+	return apl.StringArray{Dims: []int{len(v)}, Strings: v}, nil
+}
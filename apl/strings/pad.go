@@ -0,0 +1,91 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ktye/iv/apl"
+)
+
+// padLeft, padRight and center extend strings to a given width.
+// L is the width, or a vector of the width and the fill character, which defaults to a blank:
+//
+//	8 s→padleft "abc"         "     abc"
+//	8 "." s→padright "abc"    "abc....."
+//	8 "." s→center "abc"      "..abc..."
+//
+// Center puts the odd fill character to the right.
+// The width counts runes. Strings that are longer are returned unchanged, they are not truncated.
+// R is a string or an array of strings, which is padded element-wise.
+func padLeft(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return pad(a, "padleft", L, R, func(n int) (int, int) { return n, 0 })
+}
+
+func padRight(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return pad(a, "padright", L, R, func(n int) (int, int) { return 0, n })
+}
+
+func center(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	return pad(a, "center", L, R, func(n int) (int, int) { return n / 2, n - n/2 })
+}
+
+// pad calls f with the number of missing runes for each string,
+// which returns how many fill characters are prepended and appended.
+func pad(a *apl.Apl, name string, L, R apl.Value, f func(int) (int, int)) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("%s: cannot be called monadically", name)
+	}
+	width, fill, err := padArgs(a, L)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	do := func(s string) string {
+		n := width - utf8.RuneCountInString(s)
+		if n <= 0 {
+			return s
+		}
+		l, r := f(n)
+		return strings.Repeat(fill, l) + s + strings.Repeat(fill, r)
+	}
+	if s, ok := R.(apl.String); ok {
+		return apl.String(do(string(s))), nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return nil, fmt.Errorf("%s: R must be a string or an array of strings: %T", name, R)
+	}
+	res := apl.StringArray{Dims: apl.CopyShape(ar), Strings: make([]string, ar.Size())}
+	for i := range res.Strings {
+		s, ok := ar.At(i).(apl.String)
+		if ok == false {
+			return nil, fmt.Errorf("%s: R must contain only strings: %T", name, ar.At(i))
+		}
+		res.Strings[i] = do(string(s))
+	}
+	return res, nil
+}
+
+// padArgs returns the width and the fill character from the left argument.
+func padArgs(a *apl.Apl, L apl.Value) (int, string, error) {
+	fill := apl.Value(apl.String(" "))
+	if ar, ok := L.(apl.Array); ok {
+		if ar.Size() != 2 {
+			return 0, "", fmt.Errorf("L must be a width or a width and a fill character")
+		}
+		L, fill = ar.At(0), ar.At(1)
+	}
+	n, ok := L.(apl.Number)
+	if ok == false {
+		return 0, "", fmt.Errorf("width must be a number: %T", L)
+	}
+	width, ok := n.ToIndex()
+	if ok == false || width < 0 {
+		return 0, "", fmt.Errorf("width must be a non-negative integer: %s", L.String(a.Format))
+	}
+	s, ok := fill.(apl.String)
+	if ok == false || utf8.RuneCountInString(string(s)) != 1 {
+		return 0, "", fmt.Errorf("fill must be a single character: %s", fill.String(a.Format))
+	}
+	return width, string(s), nil
+}
@@ -1,4 +1,17 @@
 // Package strings provides go string functions
+//
+// The functions of the go strings package are called with go strings ("alpha"), not rune vectors.
+// The first go argument is the right argument, the second the left argument,
+// or all arguments are given as a vector on the right:
+//	3 s→repeat "ab"            ababab
+//	s→repeat "ab" 3            ababab
+//	"e" s→count "cheese"       3
+// Count does not count overlapping occurrences.
+// Runes are reversed with ⌽ on a rune vector ('abc'), a go string is a scalar.
+// Case conversion and comparison follow the Unicode case mappings:
+//	"ΣΑΣ" s→equalfold "σας"    1
+//	s→title "ça va"            Ça Va
+// Title only changes the first letter of each word, combine it with tolower to normalize.
 package strings
 
 import (
@@ -52,6 +65,17 @@ func Register(a *apl.Apl, name string) {
 		"trimright":      xgo.Function{Name: "TrimRight", Fn: reflect.ValueOf(strings.TrimRight)},
 		"trimspace":      xgo.Function{Name: "TrimSpace", Fn: reflect.ValueOf(strings.TrimSpace)},
 		"trimsuffix":     xgo.Function{Name: "TrimSuffix", Fn: reflect.ValueOf(strings.TrimSuffix)},
+		"padleft":        apl.ToFunction(padLeft),
+		"padright":       apl.ToFunction(padRight),
+		"center":         apl.ToFunction(center),
+		"template":       apl.ToFunction(template),
+		"bytes":          apl.ToFunction(toBytes),
+		"frombytes":      apl.ToFunction(fromBytes),
+		"lines":          apl.ToFunction(lines),
+		"name":           apl.ToFunction(symbolName),
+		"symbol":         apl.ToFunction(toSymbol),
+		"LOWER":          runes(lower),
+		"ALNUM":          alnum(a),
 	}
 	a.RegisterPackage(name, pkg)
 }
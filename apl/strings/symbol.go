@@ -0,0 +1,83 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ktye/iv/apl"
+)
+
+// symbolName converts a symbol (a string scalar such as `alpha) to a rune vector.
+// A vector of symbols is converted to a list of rune vectors.
+func symbolName(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
+	if s, ok := R.(apl.String); ok {
+		return runes(s), nil
+	}
+	ar, ok := R.(apl.Array)
+	if ok == false {
+		return nil, fmt.Errorf("name: R must be a symbol or a vector of symbols: %T", R)
+	}
+	if s := ar.Shape(); len(s) != 1 {
+		return nil, fmt.Errorf("name: R must have rank 1")
+	}
+	l := make(apl.List, ar.Size())
+	for i := range l {
+		s, ok := ar.At(i).(apl.String)
+		if ok == false {
+			return nil, fmt.Errorf("name: R must contain only symbols: %T", ar.At(i))
+		}
+		l[i] = runes(s)
+	}
+	return l, nil
+}
+
+// toSymbol converts a rune vector to a symbol.
+// A list of rune vectors is converted to a vector of symbols.
+func toSymbol(a *apl.Apl, _, R apl.Value) (apl.Value, error) {
+	if l, ok := R.(apl.List); ok {
+		v := apl.StringArray{Dims: []int{len(l)}, Strings: make([]string, len(l))}
+		for i := range l {
+			s, err := join(l[i])
+			if err != nil {
+				return nil, err
+			}
+			v.Strings[i] = s
+		}
+		return v, nil
+	}
+	s, err := join(R)
+	if err != nil {
+		return nil, err
+	}
+	return apl.String(s), nil
+}
+
+func runes(s apl.String) apl.StringArray {
+	r := []rune(string(s))
+	v := make([]string, len(r))
+	for i := range r {
+		v[i] = string(r[i])
+	}
+	return apl.StringArray{Dims: []int{len(v)}, Strings: v}
+}
+
+// join concatenates a rune vector to a go string.
+// A scalar string is returned as it is.
+func join(v apl.Value) (string, error) {
+	if s, ok := v.(apl.String); ok {
+		return string(s), nil
+	}
+	ar, ok := v.(apl.Array)
+	if ok == false {
+		return "", fmt.Errorf("symbol: R must be a rune vector: %T", v)
+	}
+	var b strings.Builder
+	for i := 0; i < ar.Size(); i++ {
+		s, ok := ar.At(i).(apl.String)
+		if ok == false {
+			return "", fmt.Errorf("symbol: R must be a rune vector: %T", ar.At(i))
+		}
+		b.WriteString(string(s))
+	}
+	return b.String(), nil
+}
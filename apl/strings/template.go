@@ -0,0 +1,63 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ktye/iv/apl"
+)
+
+// template replaces placeholders in the string R by values of the object L:
+//	(`name#"Bob") s→template "Hi {name}"    Hi Bob
+// A placeholder is a key in braces. Strings are inserted as they are,
+// other values are formatted with the current format.
+// Doubled braces {{ and }} are escaped and result in single braces.
+// A missing key or an unmatched brace is an error.
+func template(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("template: cannot be called monadically")
+	}
+	obj, ok := L.(apl.Object)
+	if ok == false {
+		return nil, fmt.Errorf("template: L must be an object: %T", L)
+	}
+	s, ok := R.(apl.String)
+	if ok == false {
+		return nil, fmt.Errorf("template: R must be a string: %T", R)
+	}
+
+	var b strings.Builder
+	t := string(s)
+	for len(t) > 0 {
+		i := strings.IndexAny(t, "{}")
+		if i < 0 {
+			b.WriteString(t)
+			break
+		}
+		b.WriteString(t[:i])
+		c := t[i]
+		t = t[i+1:]
+		if len(t) > 0 && t[0] == c {
+			b.WriteByte(c)
+			t = t[1:]
+			continue
+		} else if c == '}' {
+			return nil, fmt.Errorf("template: unmatched }")
+		}
+		k := strings.IndexAny(t, "{}")
+		if k < 0 || t[k] != '}' {
+			return nil, fmt.Errorf("template: unmatched {")
+		}
+		v := obj.At(apl.String(t[:k]))
+		if v == nil {
+			return nil, fmt.Errorf("template: missing key: %s", t[:k])
+		}
+		if vs, ok := v.(apl.String); ok {
+			b.WriteString(string(vs))
+		} else {
+			b.WriteString(v.String(a.Format))
+		}
+		t = t[k+1:]
+	}
+	return apl.String(b.String()), nil
+}
@@ -47,6 +47,13 @@ func (t Table) Copy() Value {
 	}
 	return r
 }
+func (t Table) DeepCopy(c Copies) Value {
+	r := Table{Rows: t.Rows}
+	if t.Dict != nil {
+		r.Dict = t.Dict.DeepCopy(c).(*Dict)
+	}
+	return r
+}
 
 // Csv writes a table in csv format.
 // If L is nil, it uses ⍕V on each value.
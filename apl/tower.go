@@ -9,11 +9,15 @@ type Tower struct {
 	Numbers map[reflect.Type]*Numeric
 	Import  func(v Number) Number       // Import Bool or Int
 	Uniform func([]Value) (Value, bool) // Values must already be uniform.
+	Prec    uint                        // Precision of floats in bits, 0 for exact towers.
+	SetPrec func(*Apl, uint)            // Changes the precision, nil if it is fixed.
 	idx     []*Numeric
 }
 
 // SetTower sets the numerical tower.
 // The default tower can be set by calling numbers.Register(a).
+// A comparison tolerance is converted to the new tower,
+// or reset to the default, if it cannot be represented.
 func (a *Apl) SetTower(t Tower) error {
 	t.idx = make([]*Numeric, len(t.Numbers))
 	for i := 0; i < len(t.Numbers); i++ {
@@ -30,6 +34,65 @@ func (a *Apl) SetTower(t Tower) error {
 		}
 	}
 	a.Tower = t
+
+	// The comparison tolerance is converted to the new tower.
+	if a.CT != nil {
+		n, err := t.Parse(a.CT.String(Format{PP: -1, RatDec: true}))
+		if err != nil {
+			a.CT = nil
+		} else {
+			a.CT = n.Number
+		}
+	}
+	return nil
+}
+
+// SetPrecision is called when a value is assigned to Quad-FPC.
+// It sets the precision in bits for new floating point numbers,
+// if the tower supports it, such as big.SetPreciseTower.
+// Existing values keep their precision.
+func (a *Apl) SetPrecision(R Value) error {
+	if a.Tower.SetPrec == nil {
+		return fmt.Errorf("FPC: the numeric tower has a fixed precision")
+	}
+	if n, ok := R.(Number); ok {
+		if i, ok := n.ToIndex(); ok && i > 1 {
+			a.Tower.SetPrec(a, uint(i))
+			return nil
+		}
+	}
+	return fmt.Errorf("illegal value for FPC: %s", R.String(a.Format))
+}
+
+// SetTolerance is called when a value is assigned to Quad-CT.
+// The comparison tolerance must be a real number between 0 and 1.
+// A tolerance of 0 compares exactly.
+func (a *Apl) SetTolerance(R Value) error {
+	n, ok := R.(Number)
+	if ok == false {
+		return fmt.Errorf("illegal value for CT: %s", R.String(a.Format))
+	}
+	for _, c := range []struct {
+		L, R Value
+	}{{n, Int(0)}, {Int(1), n}} {
+		lt, err := Primitive("<").Call(a, c.L, c.R)
+		if err != nil || lt != Bool(false) {
+			return fmt.Errorf("illegal value for CT: %s", R.String(a.Format))
+		}
+	}
+	a.CT = n
+	return nil
+}
+
+// Tolerance returns the comparison tolerance Quad-CT.
+// It returns nil, if the numeric tower cannot represent the default.
+func (a *Apl) Tolerance() Number {
+	if a.CT != nil {
+		return a.CT
+	}
+	if n, err := a.Tower.Parse("1E-14"); err == nil {
+		return n.Number
+	}
 	return nil
 }
 
@@ -94,12 +94,11 @@ func (a *Apl) Unify(A Array, uptype bool) (resultarray Array, resultok bool) {
 			}
 			return ar, true
 		} else if t0 == reflect.TypeOf(Bool(false)) {
-			ar := BoolArray{}.Make(CopyShape(A))
-			for i := 0; i < A.Size(); i++ {
-				v := A.At(i)
-				ar.(BoolArray).Bools[i] = bool(v.(Bool))
+			b := make([]bool, A.Size())
+			for i := range b {
+				b[i] = bool(A.At(i).(Bool))
 			}
-			return ar, true
+			return MakeBoolArray(CopyShape(A), b), true
 		} else if t0 == reflect.TypeOf(Int(0)) {
 			ar := IntArray{}.Make(CopyShape(A))
 			for i := 0; i < A.Size(); i++ {
@@ -23,3 +23,32 @@ type Value interface {
 type VarReader interface {
 	ReadFrom(*Apl, io.Reader) (Value, error)
 }
+
+// DeepCopier is implemented by Values which contain other values,
+// or which are copied by reference, such as namespaces and xgo values.
+// DeepCopy returns a copy that shares no state with the original.
+// Nested values are copied with c.Copy.
+type DeepCopier interface {
+	DeepCopy(c Copies) Value
+}
+
+// Copies maps reference values to their copies during a deep copy.
+// A reference that is reached again is not copied twice,
+// which keeps shared references shared and terminates cycles.
+type Copies map[interface{}]Value
+
+// Copy returns a deep copy of v.
+func (c Copies) Copy(v Value) Value {
+	if d, ok := v.(DeepCopier); ok {
+		return d.DeepCopy(c)
+	}
+	return v.Copy()
+}
+
+// DeepCopy returns a fully independent copy of v.
+// In contrast to Copy, nested namespaces and xgo values are copied as well.
+// Values that do not implement DeepCopier, are copied with their Copy method.
+// Functions and channels are still copied by reference.
+func DeepCopy(v Value) Value {
+	return make(Copies).Copy(v)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/ktye/iv/apl/scan"
@@ -31,19 +32,32 @@ func (a *Apl) Assign(name string, v Value) error {
 }
 
 // AssignEnv assigns a variable in the given environment.
+// If env is nil, the current environment is used.
+//
+// Plain assignment always creates or overwrites a variable in the current
+// environment, which shadows a variable with the same name in an outer scope.
+// Modified assignment looks up the variable first and assigns the result
+// to the environment where it was found.
+// This is used to write through to an outer scope with right tack:
+//	A←1 ⋄ {A⊢←2}0 ⋄ A   ⍝ 2: the global A is overwritten
+//	A←1 ⋄ {A⊣←2}0 ⋄ A   ⍝ 1: left tack keeps the current value
+// Both fail if the variable does not exist in any enclosing scope.
+//
+// A name with a package prefix assigns to the package variable.
+// The package is created if it does not exist.
+// Packages registered from go are read-only.
+//	mypkg→X←3 ⋄ mypkg→X   ⍝ 3
+// An uppercase prefix assigns to a member of a Namespace.
 func (a *Apl) AssignEnv(name string, v Value, env *env) error {
 	ok, isfunc := isVarname(name)
 	if ok == false {
 		return fmt.Errorf("variable name is not allowed: %s", name)
 	}
 
-	if strings.ContainsRune(name, '→') {
-		return fmt.Errorf("cannot assign to a package variable")
-	}
-
 	// Assignment to the special variable ⎕ prints the value.
 	if name == "⎕" {
 		fmt.Fprintf(a.stdout, "%s\n", v.String(a.Format))
+		a.flush()
 		return nil
 	} else if name == "⎕IO" {
 		if n, ok := v.(Number); ok {
@@ -58,12 +72,32 @@ func (a *Apl) AssignEnv(name string, v Value, env *env) error {
 		return fmt.Errorf("cannot set index origin: %T", v)
 	} else if name == "⎕PP" {
 		return a.SetPP(v)
+	} else if name == "⎕ELIDE" {
+		return a.SetElide(v)
+	} else if name == "⎕SEP" {
+		return a.setSeparator(&a.Format.Sep, v)
+	} else if name == "⎕EOL" {
+		return a.setSeparator(&a.Format.Eol, v)
+	} else if name == "⎕DEC" {
+		return a.SetDecimal(v)
+	} else if name == "⎕GROUP" {
+		return a.setSeparator(&a.Format.Group, v)
+	} else if name == "⎕RAT" {
+		return a.SetRat(v)
+	} else if name == "⎕FPC" {
+		return a.SetPrecision(v)
+	} else if name == "⎕CT" {
+		return a.SetTolerance(v)
+	} else if _, ok := sysConst[name]; ok || name == "⎕NULL" || name == "⎕NAN" || name == "⎕NOW" {
+		return fmt.Errorf("cannot assign to a system constant: %s", name)
 	}
 
-	if _, ok := v.(Function); ok && isfunc != true {
-		return fmt.Errorf("cannot assign a function to an uppercase variable")
-	} else if ok == false && isfunc == true {
-		return fmt.Errorf("only functions can be assigned to lowercase variables")
+	if err := checkKind(name, v, isfunc); err != nil {
+		return err
+	}
+
+	if n := strings.Index(name, "→"); n != -1 {
+		return a.assignPackage(name[:n], name[n+len("→"):], v)
 	}
 
 	if env == nil {
@@ -83,24 +117,68 @@ func (a *Apl) AssignEnv(name string, v Value, env *env) error {
 // Lookup returns the value stored under the given variable name.
 // It returns nil, if the variable does not exist.
 // Variables are lexically scoped.
+// A Frozen value is returned unwrapped.
 func (a *Apl) Lookup(name string) Value {
 	v, _ := a.LookupEnv(name)
-	return v
+	return Unfreeze(v)
 }
 
 // LookupEnv returns the value of a variable and a pointer to the environment,
 // where it was found.
+// In contrast to Lookup, a Frozen value is returned as it is stored.
 func (a *Apl) LookupEnv(name string) (Value, *env) {
 	if name == "⎕IO" {
 		return Int(a.Origin), nil
 	} else if name == "⎕PP" {
 		return Int(a.Format.PP), nil
+	} else if name == "⎕ELIDE" {
+		return Int(a.Format.Elide), nil
+	} else if name == "⎕SEP" {
+		return String(a.Format.Sep), nil
+	} else if name == "⎕EOL" {
+		return String(a.Format.Eol), nil
+	} else if name == "⎕DEC" {
+		if a.Format.Dec == "" {
+			return String("."), nil
+		}
+		return String(a.Format.Dec), nil
+	} else if name == "⎕GROUP" {
+		return String(a.Format.Group), nil
+	} else if name == "⎕RAT" {
+		return Bool(!a.Format.RatDec), nil
+	} else if name == "⎕FPC" {
+		return Int(a.Tower.Prec), nil
+	} else if name == "⎕CT" {
+		// The default tolerance is only available, if the numeric tower can parse it.
+		if ct := a.Tolerance(); ct != nil {
+			return ct, nil
+		}
+		return nil, nil
+	} else if name == "⎕NULL" {
+		return Null{}, nil
+	} else if name == "⎕NAN" {
+		// NaN is the result of 0÷0 in the current tower.
+		if n, err := Primitive("÷").Call(a, Int(0), Int(0)); err == nil {
+			return n, nil
+		}
+		return nil, nil
+	} else if name == "⎕NOW" {
+		// The current time is only available, if the numeric tower has a time type.
+		now := time.Now().UTC().Format("2006.01.02T15.04.05.000000000")
+		if n, err := a.Tower.Parse(now); err == nil {
+			return n.Number, nil
+		}
+		return nil, nil
+	} else if c, ok := sysConst[name]; ok {
+		return runeVector(c), nil
 	}
 
 	if idx := strings.Index(name, "→"); idx != -1 {
 		prefix := name[:idx]
 		if strings.ToLower(prefix) == prefix {
 			return a.packageVar(name), nil
+		} else if ns, ok := a.Lookup(prefix).(*Namespace); ok {
+			return ns.get(name[idx+len("→"):]), nil
 		} else {
 			return nil, nil
 		}
@@ -145,6 +223,60 @@ func (a *Apl) Vars(pkg string) ([]string, error) {
 	return l, nil
 }
 
+// GoTyper is implemented by values that wrap go values or functions, e.g. from package xgo.
+// GoType returns the go type, which is the signature for a function.
+type GoTyper interface {
+	GoType() string
+}
+
+// DescribePackage returns the variables of a package with their kind and type.
+// See Describe for the result.
+func (a *Apl) DescribePackage(pkg string) (*Dict, error) {
+	if pkg == "" {
+		return nil, fmt.Errorf("package name is empty")
+	}
+	names, err := a.Vars(pkg)
+	if err != nil {
+		return nil, err
+	}
+	e := a.pkg[pkg]
+	values := make([]Value, len(names))
+	for i, n := range names {
+		values[i] = e.vars[n]
+	}
+	return Describe(names, values), nil
+}
+
+// Describe returns a dict with the columns name, kind and type.
+// Kind is function or value and type is the go type for a GoTyper, or empty.
+func Describe(names []string, values []Value) *Dict {
+	kinds := make([]string, len(values))
+	types := make([]string, len(values))
+	for i, v := range values {
+		kinds[i] = "value"
+		if _, ok := v.(Function); ok {
+			kinds[i] = "function"
+		}
+		if t, ok := v.(GoTyper); ok {
+			types[i] = t.GoType()
+		}
+	}
+	return DescribeTable(names, kinds, types)
+}
+
+// DescribeTable returns the columns name, kind and type as a dict.
+func DescribeTable(names, kinds, types []string) *Dict {
+	column := func(s []string) Value {
+		return StringArray{Dims: []int{len(s)}, Strings: s}
+	}
+	k := []Value{String("name"), String("kind"), String("type")}
+	return &Dict{K: k, M: map[Value]Value{
+		k[0]: column(names),
+		k[1]: column(kinds),
+		k[2]: column(types),
+	}}
+}
+
 func (a *Apl) packageVar(name string) Value {
 	idx := strings.Index(name, "→")
 	if idx == -1 {
@@ -159,6 +291,41 @@ func (a *Apl) packageVar(name string) Value {
 	return pkg.vars[varname]
 }
 
+// assignPackage assigns a variable in a user package or a namespace.
+// A value name (uppercase) as a prefix refers to a variable holding a namespace.
+func (a *Apl) assignPackage(pkgname, varname string, v Value) error {
+	if ok, isfunc := isVarname(pkgname); ok == false {
+		return fmt.Errorf("package name is not allowed: %s", pkgname)
+	} else if isfunc == false {
+		x, env := a.LookupEnv(pkgname)
+		if _, ok := x.(Frozen); ok {
+			return fmt.Errorf("cannot assign to %s→%s: %s is frozen", pkgname, varname, pkgname)
+		}
+		ns, ok := x.(*Namespace)
+		if ok == false {
+			return fmt.Errorf("cannot assign to %s→%s: %s is not a namespace", pkgname, varname, pkgname)
+		}
+		// The namespace may be shared with a frozen value.
+		if y, ok := a.Thaw(ns); ok {
+			ns = y.(*Namespace)
+			if err := a.AssignEnv(pkgname, ns, env); err != nil {
+				return err
+			}
+		}
+		ns.set(varname, v)
+		return nil
+	}
+	pkg, ok := a.pkg[pkgname]
+	if ok == false {
+		pkg = &env{vars: make(map[string]Value)}
+		a.pkg[pkgname] = pkg
+	} else if pkg.readonly {
+		return fmt.Errorf("cannot assign to %s→%s: package %s is read-only", pkgname, varname, pkgname)
+	}
+	pkg.vars[varname] = v
+	return nil
+}
+
 // NumVar contains the identifier to a value.
 // The name is upper case and does not evaluate to a function.
 // NumVar evaluates to the stored value or to an Identifier if it is undeclared.
@@ -172,7 +339,9 @@ func (v numVar) String(f Format) string {
 
 func (v numVar) Eval(a *Apl) (Value, error) {
 	x := a.Lookup(v.name)
-	if x == nil {
+	if x == nil && v.name == "⎕NOW" {
+		return nil, fmt.Errorf("⎕NOW: the numeric tower has no time type")
+	} else if x == nil {
 		return Identifier(v.name), nil
 	}
 	return x, nil
@@ -207,8 +376,84 @@ func (f fnVar) Call(a *Apl, l, r Value) (Value, error) {
 	return fn.Call(a, l, r)
 }
 
-// isVarname returns if the string is allowed as a variable name and
-// referes to a number or function value.
+// sysConst are the readable system constants.
+// They are returned as rune vectors.
+//	⎕A   uppercase letters
+//	⎕D   digits
+//	⎕AV  atomic vector: the first 256 code points
+var sysConst = map[string]string{
+	"⎕A":  "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"⎕D":  "0123456789",
+	"⎕AV": atomicVector(),
+}
+
+func atomicVector() string {
+	r := make([]rune, 256)
+	for i := range r {
+		r[i] = rune(i)
+	}
+	return string(r)
+}
+
+// runeVector returns a string as a vector of single-rune strings.
+func runeVector(s string) StringArray {
+	r := []rune(s)
+	v := make([]string, len(r))
+	for i := range r {
+		v[i] = string(r[i])
+	}
+	return StringArray{Dims: []int{len(v)}, Strings: v}
+}
+
+// IsVarname returns if the string is allowed as a variable name
+// and if it is a function name.
+//
+// Function names start with a lowercase letter, all other names hold values.
+// For a package variable, the part after → is tested.
+//	IsVarname("X")      ⍝ true false
+//	IsVarname("f")      ⍝ true true
+//	IsVarname("pkg→F")  ⍝ true false
+func IsVarname(s string) (ok, isfunc bool) {
+	return isVarname(s)
+}
+
+// checkKind returns an error, if a function is assigned to a value name or vice versa.
+func checkKind(name string, v Value, isfunc bool) error {
+	if _, ok := v.(Function); ok && isfunc != true {
+		return fmt.Errorf("cannot assign a function to %s: it is a value name (uppercase)%s", name, suggestName(name))
+	} else if ok == false && isfunc == true {
+		return fmt.Errorf("cannot assign a value (%T) to %s: it is a function name (lowercase)%s", v, name, suggestName(name))
+	}
+	return nil
+}
+
+// suggestName returns a hint to use the name with the first letter in the other case.
+// It returns an empty string, if the case cannot be changed, e.g. for ⍺.
+func suggestName(name string) string {
+	prefix := ""
+	if n := strings.Index(name, "→"); n != -1 {
+		prefix, name = name[:n+len("→")], name[n+len("→"):]
+	}
+	r := []rune(name)
+	if len(r) == 0 {
+		return ""
+	}
+	c := unicode.ToLower(r[0])
+	if unicode.IsLower(r[0]) {
+		c = unicode.ToUpper(r[0])
+	}
+	if c == r[0] {
+		return ""
+	}
+	r[0] = c
+	alt := prefix + string(r)
+	if ok, _ := isVarname(alt); ok == false {
+		return ""
+	}
+	return ", use " + alt + " instead"
+}
+
+// isVarname is the implementation of IsVarname.
 func isVarname(s string) (ok, isfunc bool) {
 	if s == "" {
 		return false, false
@@ -0,0 +1,117 @@
+package apl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+)
+
+func TestIsVarname(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ok, isfunc bool
+	}{
+		{"X", true, false},
+		{"Alpha", true, false},
+		{"f", true, true},
+		{"sum", true, true},
+		{"⍺", true, false},
+		{"pkg→F", true, false},
+		{"pkg→f", true, true},
+		{"", false, false},
+		{"1X", false, false},
+	}
+	for _, tc := range testCases {
+		if ok, isfunc := IsVarname(tc.name); ok != tc.ok || isfunc != tc.isfunc {
+			t.Fatalf("%q: expected %v %v, got %v %v", tc.name, tc.ok, tc.isfunc, ok, isfunc)
+		}
+	}
+}
+
+func TestAssignKind(t *testing.T) {
+	testCases := []struct {
+		name string
+		v    Value
+		err  string
+	}{
+		{"X", Int(1), ""},
+		{"f", Primitive("+"), ""},
+		{"Sum", Primitive("+"), "cannot assign a function to Sum: it is a value name (uppercase), use sum instead"},
+		{"sum", IntArray{Dims: []int{2}, Ints: []int{1, 2}}, "cannot assign a value (apl.IntArray) to sum: it is a function name (lowercase), use Sum instead"},
+		{"⍺", Primitive("+"), "cannot assign a function to ⍺: it is a value name (uppercase)"},
+	}
+	a := New(ioutil.Discard)
+	for _, tc := range testCases {
+		err := a.Assign(tc.name, tc.v)
+		if tc.err == "" && err != nil {
+			t.Fatalf("%s: %s", tc.name, err)
+		} else if tc.err != "" && (err == nil || err.Error() != tc.err) {
+			t.Fatalf("%s: expected error %q, got %v", tc.name, tc.err, err)
+		}
+	}
+}
+
+func TestAssignPackage(t *testing.T) {
+	a := New(ioutil.Discard)
+	if err := a.Assign("mypkg→X", Int(3)); err != nil {
+		t.Fatal(err)
+	}
+	if v := a.Lookup("mypkg→X"); v != Int(3) {
+		t.Fatalf("expected 3, got %v", v)
+	}
+	if err := a.Assign("mypkg→f", Primitive("+")); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := a.Vars("mypkg"); err != nil || len(l) != 2 {
+		t.Fatalf("expected 2 package variables, got %v %v", l, err)
+	}
+	if v := a.Lookup("X"); v != nil {
+		t.Fatalf("package variable leaked into the root environment: %v", v)
+	}
+
+	a.RegisterPackage("ro", map[string]Value{"X": Int(1)})
+	if err := a.Assign("ro→X", Int(2)); err == nil {
+		t.Fatal("expected error for read-only package")
+	}
+	if err := a.Assign("Pkg→X", Int(2)); err == nil {
+		t.Fatal("expected error for uppercase package name")
+	}
+}
+
+func TestForkPackage(t *testing.T) {
+	a := New(ioutil.Discard)
+	if err := a.Assign("mypkg→X", Int(1)); err != nil {
+		t.Fatal(err)
+	}
+	ns := NewNamespace()
+	if err := a.Assign("N", ns); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		b := a.Fork()
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				if err := b.Assign("mypkg→X", Int(i)); err != nil {
+					t.Error(err)
+				}
+				if err := b.Assign(fmt.Sprintf("N→X%d", i), Int(k)); err != nil {
+					t.Error(err)
+				}
+				b.Lookup("N→X0")
+				ns.Keys()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if v := a.Lookup("mypkg→X"); v != Int(1) {
+		t.Fatalf("fork modified the package of the parent: %v", v)
+	}
+	if n := len(ns.Keys()); n != 4 {
+		t.Fatalf("expected 4 namespace members, got %d", n)
+	}
+}
@@ -0,0 +1,102 @@
+package apl
+
+// ViewThreshold is the minimum size of the result of a structural function
+// like take, drop or transpose, that is returned as a View.
+// Smaller results are copied directly.
+var ViewThreshold = 1 << 12
+
+// View is a lazy array that references the elements of a parent array.
+// Element i is the parent element at Index[i], or Fill if the index is negative.
+//
+// A View is returned by structural primitives (↑ ↓ ⍉) for large arrays to avoid
+// copying elements. Chained views reference the original parent.
+// Copy materializes the view. Variables store copies, so a view is never
+// the target of an indexed assignment.
+// The parent however may be the value of a variable, that is modified in place
+// later. A new view copies it, such that it owns its parent.
+type View struct {
+	Dims   []int
+	Index  []int
+	Fill   Value
+	Parent Array
+}
+
+// NewView returns a view of shape and index into parent.
+// If parent is a view itself, the indexes are composed.
+// A parent view that references its own fill elements cannot be composed,
+// because the view has only a single fill value. It is materialized instead.
+// For sizes below ViewThreshold, the result is materialized immediately.
+func NewView(parent Array, shape []int, index []int, fill Value) Array {
+	own := false
+	if p, ok := parent.(View); ok {
+		idx := make([]int, len(index))
+		compose := true
+		for i, k := range index {
+			if k < 0 {
+				idx[i] = -1
+			} else if idx[i] = p.Index[k]; idx[i] < 0 {
+				compose = false
+				break
+			}
+		}
+		if compose {
+			index = idx
+			parent = p.Parent
+		} else {
+			parent = p.Materialize()
+		}
+		own = true
+	}
+	v := View{Dims: shape, Index: index, Fill: fill, Parent: parent}
+	if len(index) < ViewThreshold {
+		return v.Materialize()
+	}
+	if own == false {
+		v.Parent = parent.Copy().(Array)
+	}
+	return v
+}
+
+func (v View) String(f Format) string {
+	return ArrayString(f, v)
+}
+
+// Copy returns the materialized array.
+func (v View) Copy() Value {
+	return v.Materialize()
+}
+
+func (v View) DeepCopy(c Copies) Value {
+	return c.Copy(v.Materialize())
+}
+
+func (v View) At(i int) Value {
+	if k := v.Index[i]; k >= 0 {
+		return v.Parent.At(k)
+	}
+	return v.Fill
+}
+
+func (v View) Shape() []int {
+	return v.Dims
+}
+
+func (v View) Size() int {
+	return len(v.Index)
+}
+
+// Materialize copies the elements into a new array.
+// The result has the type of the parent, if possible.
+func (v View) Materialize() Array {
+	res := MakeArray(v.Parent, CopyShape(v))
+	for i := range v.Index {
+		if err := res.Set(i, v.At(i).Copy()); err != nil {
+			m := NewMixed(CopyShape(v))
+			for k := range m.Values {
+				m.Values[k] = v.At(k).Copy()
+			}
+			return m
+		}
+	}
+	return res
+}
@@ -0,0 +1,41 @@
+package xgo
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/ktye/iv/apl"
+)
+
+// RegisterConstants registers go constants as a read-only package.
+// The map keys are the names in apl, which must be value names (uppercase).
+// Values are converted, a typed constant is converted by it's kind.
+//	const (
+//		Red Color = iota
+//		Green
+//		Blue
+//	)
+//	RegisterConstants(a, "color", map[string]interface{}{"Red": Red, "Green": Green, "Blue": Blue})
+// In apl, the constants are referenced as color→Red.
+func RegisterConstants(a *apl.Apl, name string, m map[string]interface{}) error {
+	names := make([]string, 0, len(m))
+	for k := range m {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	pkg := make(map[string]apl.Value)
+	for _, k := range names {
+		if ok, isfunc := apl.IsVarname(k); ok == false || isfunc {
+			return fmt.Errorf("xgo: constant %s: name must be a value name (uppercase)", k)
+		}
+		v, err := Convert(reflect.ValueOf(m[k]))
+		if err != nil {
+			return fmt.Errorf("xgo: constant %s: %s", k, err)
+		}
+		pkg[k] = v
+	}
+	a.RegisterPackage(name, pkg)
+	return nil
+}
@@ -0,0 +1,16 @@
+package xgo
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+)
+
+// Constants are tested in apl/primitives/apl_test.go.
+func TestConstantName(t *testing.T) {
+	a := apl.New(ioutil.Discard)
+	if err := RegisterConstants(a, "color", map[string]interface{}{"red": 0}); err == nil {
+		t.Fatal("expected error for a function name")
+	}
+}
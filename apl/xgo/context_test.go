@@ -0,0 +1,40 @@
+package xgo
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ktye/iv/apl"
+)
+
+func TestContext(t *testing.T) {
+	a := apl.New(ioutil.Discard)
+	x := Value(reflect.ValueOf(&T{}))
+	sleep, ok := x.At(apl.String("sleep")).(Function)
+	if ok == false {
+		t.Fatal("method sleep does not exist")
+	}
+
+	// Without cancelation, the method returns normally.
+	if _, err := sleep.Call(a, nil, apl.Int(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.SetContext(ctx)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err := sleep.Call(a, nil, apl.Int(60000))
+	if err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Fatalf("canceled call returned after %v", d)
+	}
+}
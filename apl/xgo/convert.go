@@ -27,16 +27,28 @@ func export(v apl.Value, t reflect.Type) (reflect.Value, error) {
 	switch t.Kind() {
 
 	case reflect.Int:
-		return reflect.ValueOf(int(v.(apl.Int))), nil
+		if i, ok := v.(apl.Int); ok {
+			return reflect.ValueOf(int(i)), nil
+		}
+		return zero, fmt.Errorf("expected int: %T", v)
 
 	case reflect.Float64:
-		return reflect.ValueOf(float64(v.(numbers.Float))), nil
+		if f, ok := v.(numbers.Float); ok {
+			return reflect.ValueOf(float64(f)), nil
+		}
+		return zero, fmt.Errorf("expected float64: %T", v)
 
 	case reflect.Complex128:
-		return reflect.ValueOf(complex128(v.(numbers.Complex))), nil
+		if c, ok := v.(numbers.Complex); ok {
+			return reflect.ValueOf(complex128(c)), nil
+		}
+		return zero, fmt.Errorf("expected complex128: %T", v)
 
 	case reflect.String:
-		return reflect.ValueOf(string(v.(apl.String))), nil
+		if s, ok := v.(apl.String); ok {
+			return reflect.ValueOf(string(s)), nil
+		}
+		return zero, fmt.Errorf("expected string: %T", v)
 
 	case reflect.Slice:
 		ar, ok := v.(apl.Array)
@@ -44,11 +56,14 @@ func export(v apl.Value, t reflect.Type) (reflect.Value, error) {
 			return zero, fmt.Errorf("expected slice: %T", v)
 		}
 		et := t.Elem()
+		if shape := ar.Shape(); len(shape) == 2 && et.Kind() == reflect.Struct {
+			ar = rows(ar, shape)
+		}
 		n := ar.Size()
 		s := reflect.MakeSlice(t, n, n)
 		for i := 0; i < n; i++ {
 			if e, err := export(ar.At(i), et); err != nil {
-				return zero, err
+				return zero, fmt.Errorf("%s[%d]: %s", t, i, err)
 			} else {
 				se := s.Index(i)
 				se.Set(e)
@@ -56,6 +71,25 @@ func export(v apl.Value, t reflect.Type) (reflect.Value, error) {
 		}
 		return s, nil
 
+	case reflect.Interface:
+		return exportInterface(v, t)
+
+	case reflect.Ptr:
+		// ⎕NULL is a nil pointer, an xgo value of the same type is kept as a reference.
+		// Other values are converted to the element type and a pointer to a copy is returned.
+		if _, ok := v.(apl.Null); ok {
+			return reflect.Zero(t), nil
+		} else if xv, ok := v.(Value); ok && reflect.Value(xv).Type() == t {
+			return reflect.Value(xv), nil
+		}
+		e, err := export(v, t.Elem())
+		if err != nil {
+			return zero, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(e)
+		return p, nil
+
 	case reflect.Struct:
 		if xv, ok := v.(Value); ok {
 			st := reflect.Value(xv).Type()
@@ -65,19 +99,127 @@ func export(v apl.Value, t reflect.Type) (reflect.Value, error) {
 				return reflect.Value(xv), nil
 			}
 		}
-		return zero, fmt.Errorf("xgo: export struct: cannot convert %T to %s", v, t)
+		return exportStruct(v, t)
 
 	default:
 		return zero, fmt.Errorf("cannot convert to %v (%s)", t, t.Kind())
 	}
 }
 
+// exportInterface packs an apl value into a go interface.
+// Scalars are converted to their natural go types: bool, int, float64, complex128 or string.
+// Arrays are converted to []interface{} and ⎕NULL to a nil interface.
+// The result must implement the interface type.
+func exportInterface(v apl.Value, t reflect.Type) (reflect.Value, error) {
+	zero := reflect.Value{}
+	var x reflect.Value
+	switch u := v.(type) {
+	case Exporter:
+		x = u.Export()
+	case Value:
+		x = reflect.Value(u)
+	case apl.Null:
+		return reflect.Zero(t), nil
+	case apl.Bool:
+		x = reflect.ValueOf(bool(u))
+	case apl.Int:
+		x = reflect.ValueOf(int(u))
+	case numbers.Float:
+		x = reflect.ValueOf(float64(u))
+	case numbers.Complex:
+		x = reflect.ValueOf(complex128(u))
+	case apl.String:
+		x = reflect.ValueOf(string(u))
+	case apl.Array:
+		var err error
+		x, err = export(u, reflect.TypeOf([]interface{}{}))
+		if err != nil {
+			return zero, err
+		}
+	default:
+		return zero, fmt.Errorf("xgo: export interface: cannot convert %T to %s", v, t)
+	}
+	if x.Type().Implements(t) == false {
+		return zero, fmt.Errorf("xgo: export interface: %s does not implement %s", x.Type(), t)
+	}
+	r := reflect.New(t).Elem()
+	r.Set(x)
+	return r, nil
+}
+
+// rows splits a matrix into a list of row vectors.
+// A matrix is exported to a slice of structs row by row.
+func rows(ar apl.Array, shape []int) apl.List {
+	l := make(apl.List, shape[0])
+	for i := range l {
+		row := apl.NewMixed([]int{shape[1]})
+		for k := range row.Values {
+			row.Values[k] = ar.At(i*shape[1] + k)
+		}
+		l[i] = row
+	}
+	return l
+}
+
+// exportStruct converts an object or a vector to a go struct.
+// Object keys are matched with the field names, the first letter may be lowercase.
+// Vector elements are assigned to the fields in order.
+// Missing fields keep their zero value, extra keys or elements are an error.
+func exportStruct(v apl.Value, t reflect.Type) (reflect.Value, error) {
+	zero := reflect.Value{}
+	s := reflect.New(t).Elem()
+	setField := func(i int, x apl.Value) error {
+		if e, err := export(x, t.Field(i).Type); err != nil {
+			return fmt.Errorf("field %s: %s", t.Field(i).Name, err)
+		} else {
+			s.Field(i).Set(e)
+		}
+		return nil
+	}
+	if obj, ok := v.(apl.Object); ok {
+		for _, k := range obj.Keys() {
+			name, ok := k.(apl.String)
+			if ok == false {
+				return zero, fmt.Errorf("xgo: export struct %s: key is not a string: %T", t, k)
+			}
+			f, ok := t.FieldByName(string(name))
+			if ok == false {
+				f, ok = t.FieldByName(upper(string(name)))
+			}
+			if ok == false || len(f.Index) != 1 || f.PkgPath != "" {
+				return zero, fmt.Errorf("xgo: export struct %s: field does not exist: %s", t, name)
+			}
+			if err := setField(f.Index[0], obj.At(k)); err != nil {
+				return zero, fmt.Errorf("xgo: export struct %s: %s", t, err)
+			}
+		}
+		return s, nil
+	} else if ar, ok := v.(apl.Array); ok {
+		if n := ar.Size(); n > t.NumField() {
+			return zero, fmt.Errorf("xgo: export struct %s: too many values: %d > %d fields", t, n, t.NumField())
+		}
+		for i := 0; i < ar.Size(); i++ {
+			if t.Field(i).PkgPath != "" {
+				return zero, fmt.Errorf("xgo: export struct %s: field %s is not exported", t, t.Field(i).Name)
+			}
+			if err := setField(i, ar.At(i)); err != nil {
+				return zero, fmt.Errorf("xgo: export struct %s: %s", t, err)
+			}
+		}
+		return s, nil
+	}
+	return zero, fmt.Errorf("xgo: export struct: cannot convert %T to %s", v, t)
+}
+
 // convert converts a go value to an apl value.
 func Convert(v reflect.Value) (apl.Value, error) {
 	switch v.Kind() {
 	case reflect.Int:
 		return apl.Int(int(v.Int())), nil
 
+	case reflect.Bool:
+		return apl.Bool(v.Bool()), nil
+
 	case reflect.Uint:
 		return apl.Int(int(v.Uint())), nil
 
@@ -103,7 +245,32 @@ func Convert(v reflect.Value) (apl.Value, error) {
 		return ar, nil
 
 	case reflect.Struct:
-		return Value(v.Addr()), nil // TODO: populate
+		// Structs are kept as references, e.g. to the elements of a slice.
+		// A struct that is not addressable is copied.
+		if v.CanAddr() == false {
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			return Value(p), nil
+		}
+		return Value(v.Addr()), nil
+
+	case reflect.Ptr:
+		// Pointers to structs and values implementing io.Reader or io.Writer
+		// are kept as references. A nil pointer is ⎕NULL.
+		if v.IsNil() {
+			return apl.Null{}, nil
+		}
+		if t := v.Type(); t.Elem().Kind() == reflect.Struct || t.Implements(readerType) || t.Implements(writerType) {
+			return Value(v), nil
+		}
+		return Convert(v.Elem())
+
+	case reflect.Interface:
+		// An interface is converted by it's dynamic type, a nil interface is ⎕NULL.
+		if v.IsNil() {
+			return apl.Null{}, nil
+		}
+		return Convert(v.Elem())
 
 	default:
 		return nil, fmt.Errorf("cannot convert %s to an apl value", v.Kind())
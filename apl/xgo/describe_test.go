@@ -0,0 +1,36 @@
+package xgo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+)
+
+// describeT is a fixed type for testing Describe.
+// It should not be extended by examples.
+type describeT struct {
+	A int
+	B []string
+}
+
+func (d describeT) Sum(x int) int { return d.A + x }
+func (d *describeT) Reset()       { d.A = 0 }
+
+func TestDescribe(t *testing.T) {
+	d := Value(reflect.ValueOf(&describeT{})).Describe()
+	exp := map[string][]string{
+		"name": {"A", "B", "reset", "sum"},
+		"kind": {"value", "value", "function", "function"},
+		"type": {"int", "[]string", "func()", "func(int) int"},
+	}
+	for k, e := range exp {
+		col, ok := d.At(apl.String(k)).(apl.StringArray)
+		if ok == false {
+			t.Fatalf("column %s is missing: %T", k, d.At(apl.String(k)))
+		}
+		if reflect.DeepEqual(col.Strings, e) == false {
+			t.Fatalf("%s: expected %v, got %v", k, e, col.Strings)
+		}
+	}
+}
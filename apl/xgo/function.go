@@ -1,8 +1,10 @@
 package xgo
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/ktye/iv/apl"
 )
@@ -12,38 +14,62 @@ type Function struct {
 	Fn   reflect.Value
 }
 
+// String returns the name and the go signature of the function, e.g.
+//	ToUpper(string) string
 func (f Function) String(af apl.Format) string {
-	return f.Name
+	return f.Name + signature(f.Fn.Type())
 }
 func (f Function) Copy() apl.Value { return f }
 
+// GoType returns the signature of the go function.
+func (f Function) GoType() string { return f.Fn.Type().String() }
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// signature returns the parameter and result types of a function type.
+func signature(t reflect.Type) string {
+	return strings.TrimPrefix(t.String(), "func")
+}
+
 // Call a go function.
 // If it requires 1 argument, that is taken from the right value.
 // Two arguments may be the right and left argument or a vector of 2 arguments.
 // More than two arguments must be passed in a vector of the right size.
+// If the first argument is a context.Context, the context of the interpreter is passed.
 // If the function returns an error as the last value, it is checked and returned.
 // Otherwise, or if the error is nil the result is converted and returned.
-// More than one result will be returned as a List.
+// More than one result will be returned as a List, e.g. (int, string, bool) as (1;"a";1;).
 func (f Function) Call(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 	errarg := func(i int, err error) error {
 		return fmt.Errorf("function %s argument %d: %s", f.Name, i+1, err)
 	}
 	t := f.Fn.Type()
 	args := t.NumIn()
-	in := make([]reflect.Value, args)
+	all := make([]reflect.Value, args)
+
+	// A context.Context as the first argument is passed from the interpreter.
+	// The remaining arguments are taken from L and R.
+	off := 0
+	if args > 0 && t.In(0) == contextType {
+		all[0] = reflect.ValueOf(a.Context())
+		args--
+		off = 1
+	}
+	in := all[off:]
+
 	var err error
 	if args == 0 {
 	} else if args == 1 {
-		in[0], err = export(R, t.In(0))
+		in[0], err = export(R, t.In(off))
 		if err != nil {
 			return nil, errarg(0, err)
 		}
 	} else if args == 2 && L != nil {
-		in[0], err = export(R, t.In(0))
+		in[0], err = export(R, t.In(off))
 		if err != nil {
 			return nil, errarg(0, err)
 		}
-		in[1], err = export(L, t.In(1))
+		in[1], err = export(L, t.In(off+1))
 		if err != nil {
 			return nil, errarg(1, err)
 		}
@@ -60,14 +86,14 @@ func (f Function) Call(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 			return nil, fmt.Errorf("function %s requires %d arguments, R has size %d", f.Name, args, n)
 		} else {
 			for i := 0; i < args; i++ {
-				in[i], err = export(ar.At(i), t.In(i))
+				in[i], err = export(ar.At(i), t.In(off+i))
 				if err != nil {
 					return nil, errarg(i, err)
 				}
 			}
 		}
 	}
-	out := f.Fn.Call(in)
+	out := f.Fn.Call(all)
 
 	// Test if the last output value is an error, check and remove it.
 	if len(out) > 0 {
@@ -88,7 +114,7 @@ func (f Function) Call(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 		res := make(apl.List, len(out))
 		for i := range out {
 			if v, err := Convert(out[i]); err != nil {
-				return nil, err
+				return nil, fmt.Errorf("function %s result %d: %s", f.Name, i+1, err)
 			} else {
 				res[i] = v
 			}
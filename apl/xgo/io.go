@@ -0,0 +1,120 @@
+package xgo
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/ktye/iv/apl"
+)
+
+var (
+	readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+	writerType = reflect.TypeOf((*io.Writer)(nil)).Elem()
+)
+
+// read reads from an io.Reader.
+// Called monadically it reads until EOF.
+// With a left argument N it reads at most N bytes.
+// The result may be shorter, if less data is available.
+// At EOF, an empty array is returned.
+//	N go→read R
+type read struct{}
+
+func (_ read) String(f apl.Format) string {
+	return "read"
+}
+func (r read) Copy() apl.Value { return r }
+
+func (_ read) Call(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	rd, ok := stream(R, readerType).(io.Reader)
+	if ok == false {
+		return nil, fmt.Errorf("read: R must be an io.Reader: %T", R)
+	}
+	if L == nil {
+		b, err := ioutil.ReadAll(rd)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) == 0 {
+			return apl.EmptyArray{}, nil
+		}
+		return apl.String(b), nil
+	}
+	num, ok := L.(apl.Number)
+	if ok == false {
+		return nil, fmt.Errorf("read: L must be a number")
+	}
+	n, ok := num.ToIndex()
+	if ok == false || n < 0 {
+		return nil, fmt.Errorf("read: L must be a non-negative integer")
+	}
+	b := make([]byte, n)
+	m, err := io.ReadFull(rd, b)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m == 0 && n > 0 {
+		return apl.EmptyArray{}, nil
+	}
+	return apl.String(b[:m]), nil
+}
+
+// write writes a string or character vector R to the io.Writer L.
+// It returns the number of bytes written.
+//	L go→write R
+type write struct{}
+
+func (_ write) String(f apl.Format) string {
+	return "write"
+}
+func (w write) Copy() apl.Value { return w }
+
+func (_ write) Call(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
+	if L == nil {
+		return nil, fmt.Errorf("write: L must be an io.Writer")
+	}
+	wr, ok := stream(L, writerType).(io.Writer)
+	if ok == false {
+		return nil, fmt.Errorf("write: L must be an io.Writer: %T", L)
+	}
+	var s string
+	if str, ok := R.(apl.String); ok {
+		s = string(str)
+	} else if ar, ok := R.(apl.Array); ok {
+		var b strings.Builder
+		for i := 0; i < ar.Size(); i++ {
+			str, ok := ar.At(i).(apl.String)
+			if ok == false {
+				return nil, fmt.Errorf("write: R must be a string: %T", ar.At(i))
+			}
+			b.WriteString(string(str))
+		}
+		s = b.String()
+	} else {
+		return nil, fmt.Errorf("write: R must be a string: %T", R)
+	}
+	n, err := io.WriteString(wr, s)
+	if err != nil {
+		return nil, err
+	}
+	return apl.Int(n), nil
+}
+
+// stream returns the go value of v, if it implements the interface t.
+func stream(v apl.Value, t reflect.Type) interface{} {
+	xv, ok := v.(Value)
+	if ok == false {
+		return nil
+	}
+	rv := reflect.Value(xv)
+	if rv.IsValid() == false || rv.Type().Implements(t) == false {
+		return nil
+	}
+	return rv.Interface()
+}
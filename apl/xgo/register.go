@@ -1,9 +1,12 @@
 package xgo
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/ktye/iv/apl"
 )
@@ -13,11 +16,17 @@ func Register(a *apl.Apl, name string) {
 		name = "go"
 	}
 	pkg := map[string]apl.Value{
-		"t":      New(reflect.TypeOf(T{})),
-		"s":      New(reflect.TypeOf(S{})),
-		"i":      New(reflect.TypeOf(I(0))),
-		"source": source{},
-		"echo":   echo{},
+		"t":       New(reflect.TypeOf(T{})),
+		"s":       New(reflect.TypeOf(S{})),
+		"i":       New(reflect.TypeOf(I(0))),
+		"source":  source{},
+		"counter": New(reflect.TypeOf(Counter{})),
+		"mutex":   New(reflect.TypeOf(Mutex{})),
+		"echo":    echo{},
+		"buffer":  New(reflect.TypeOf(bytes.Buffer{})),
+		"reader":  Function{Name: "NewReader", Fn: reflect.ValueOf(strings.NewReader)},
+		"read":    read{},
+		"write":   write{},
 	}
 	a.RegisterPackage("go", pkg)
 }
@@ -26,18 +35,84 @@ type I int
 
 // T is an example struct with methods with pointer receivers.
 type T struct {
-	A string
-	I int
-	F float64
-	C complex128
-	V []string
-	S S
+	A  string
+	I  int
+	F  float64
+	C  complex128
+	V  []string
+	S  S
+	Ch chan int
+	P  []Point
+	E  interface{}
+	In *Inner
+}
+
+// Inner is an example struct referenced by a pointer field.
+type Inner struct {
+	A    int
+	Next *Inner
+}
+
+// Point is an example struct used as a slice element.
+type Point struct {
+	X, Y int
 }
 
 func (t *T) Inc() {
 	t.I++
 }
 
+// Open creates a buffered channel with capacity n.
+func (t *T) Open(n int) {
+	t.Ch = make(chan int, n)
+}
+
+// Close closes the channel.
+func (t *T) Close() {
+	close(t.Ch)
+}
+
+// Dynamic returns the dynamic type of the interface field E.
+func (t *T) Dynamic() string {
+	return fmt.Sprintf("%T", t.E)
+}
+
+// Find returns the index of sep in A, the remainder after it and if it was found.
+// It is an example of a method with more than two results.
+func (t *T) Find(sep string) (int, string, bool) {
+	i := strings.Index(t.A, sep)
+	if i < 0 {
+		return -1, "", false
+	}
+	return i, t.A[i+len(sep):], true
+}
+
+// MustFind is like Find, but returns an error if sep is not found.
+func (t *T) MustFind(sep string) (int, string, bool, error) {
+	i, s, ok := t.Find(sep)
+	if ok == false {
+		return i, s, ok, fmt.Errorf("%q not found", sep)
+	}
+	return i, s, ok, nil
+}
+
+// Sleep waits for ms milliseconds or until the context is canceled.
+// It is an example of a method that accepts a context.
+func (t *T) Sleep(ctx context.Context, ms int) error {
+	select {
+	case <-time.After(time.Duration(ms) * time.Millisecond):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetS replaces the field S.
+// It is an example of a method with a struct argument.
+func (t *T) SetS(s S) {
+	t.S = s
+}
+
 func (t *T) Join(sep string) (int, string) {
 	s := strings.Join(t.V, sep)
 	return len(t.V), s
@@ -0,0 +1,44 @@
+package xgo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a concurrency-safe counter.
+// It can be shared between functions running in parallel (f&).
+//	C←go→counter 0 ⋄ C[`inc]⍨0 ⋄ C[`add]⍨5 ⋄ C[`get]⍨0
+type Counter struct {
+	n int64
+}
+
+// Inc increments the counter and returns the new value.
+func (c *Counter) Inc() int {
+	return int(atomic.AddInt64(&c.n, 1))
+}
+
+// Add adds n to the counter and returns the new value.
+func (c *Counter) Add(n int) int {
+	return int(atomic.AddInt64(&c.n, int64(n)))
+}
+
+// Get returns the current value.
+func (c *Counter) Get() int {
+	return int(atomic.LoadInt64(&c.n))
+}
+
+// Mutex is a mutual exclusion lock.
+//	M←go→mutex 0 ⋄ M[`lock]⍨0 ⋄ ... ⋄ M[`unlock]⍨0
+type Mutex struct {
+	m sync.Mutex
+}
+
+// Lock blocks until the mutex is available.
+func (m *Mutex) Lock() {
+	m.m.Lock()
+}
+
+// Unlock releases the mutex.
+func (m *Mutex) Unlock() {
+	m.m.Unlock()
+}
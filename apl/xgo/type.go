@@ -22,6 +22,88 @@ func (v Value) Copy() apl.Value {
 	return v
 }
 
+// DeepCopy returns a copy of the underlying go value.
+// Pointers, slices, arrays, maps and exported struct fields are followed.
+// Channels, functions and unexported fields are still shared.
+// Pointers and maps that are reached again, refer to the same copy.
+func (v Value) DeepCopy(c apl.Copies) apl.Value {
+	return Value(deepCopy(reflect.Value(v), c))
+}
+
+// Ref returns the identity of pointer and map values, see apl.Referencer.
+func (v Value) Ref() interface{} {
+	r := reflect.Value(v)
+	if k := r.Kind(); (k == reflect.Ptr || k == reflect.Map) && r.IsNil() == false {
+		return reference{r.Type(), r.Pointer()}
+	}
+	return nil
+}
+
+// reference identifies a pointer or map in the copies.
+type reference struct {
+	t reflect.Type
+	p uintptr
+}
+
+func deepCopy(v reflect.Value, c apl.Copies) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		ref := reference{v.Type(), v.Pointer()}
+		if r, ok := c[ref]; ok {
+			return reflect.Value(r.(Value))
+		}
+		r := reflect.New(v.Type().Elem())
+		c[ref] = Value(r)
+		r.Elem().Set(deepCopy(v.Elem(), c))
+		return r
+	case reflect.Struct:
+		r := reflect.New(v.Type()).Elem()
+		r.Set(v)
+		for i := 0; i < r.NumField(); i++ {
+			if f := r.Field(i); f.CanSet() {
+				f.Set(deepCopy(v.Field(i), c))
+			}
+		}
+		return r
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		r := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			r.Index(i).Set(deepCopy(v.Index(i), c))
+		}
+		return r
+	case reflect.Array:
+		r := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			r.Index(i).Set(deepCopy(v.Index(i), c))
+		}
+		return r
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		ref := reference{v.Type(), v.Pointer()}
+		if r, ok := c[ref]; ok {
+			return reflect.Value(r.(Value))
+		}
+		r := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c[ref] = Value(r)
+		for _, k := range v.MapKeys() {
+			r.SetMapIndex(k, deepCopy(v.MapIndex(k), c))
+		}
+		return r
+	}
+	return v
+}
+
+// String displays the fields with their values, followed by the methods with their signature:
+//	A:   3
+//	sum: method() int
 func (v Value) String(f apl.Format) string {
 	keys := v.Keys()
 	if keys == nil {
@@ -30,8 +112,13 @@ func (v Value) String(f apl.Format) string {
 	var buf strings.Builder
 	tw := tabwriter.NewWriter(&buf, 1, 0, 1, ' ', 0)
 	for _, k := range keys {
-		val := v.At(k)
 		s := ""
+		if c, ok := v.chanField(k); ok {
+			// Do not receive from a channel, when printing.
+			fmt.Fprintf(tw, "%s:\t%v\n", k.String(f), c.Type())
+			continue
+		}
+		val := v.At(k)
 		if val == nil {
 			s = "?"
 		} else {
@@ -39,6 +126,10 @@ func (v Value) String(f apl.Format) string {
 		}
 		fmt.Fprintf(tw, "%s:\t%s\n", k.String(f), s)
 	}
+	val := reflect.Value(v)
+	for i := 0; i < val.NumMethod(); i++ {
+		fmt.Fprintf(tw, "%s:\tmethod%s\n", lower(val.Type().Method(i).Name), signature(val.Method(i).Type()))
+	}
 	tw.Flush()
 	s := buf.String()
 	if len(s) > 0 && s[len(s)-1] == '\n' {
@@ -47,14 +138,37 @@ func (v Value) String(f apl.Format) string {
 	return s
 }
 
+// GoType returns the go type of the value.
+func (v Value) GoType() string {
+	return reflect.Value(v).Type().String()
+}
+
+// Describe returns the fields and methods with their go types.
+// See apl.Describe for the result.
+func (v Value) Describe() *apl.Dict {
+	var names, kinds, types []string
+	if val := indirect(reflect.Value(v)); val.Kind() == reflect.Struct {
+		t := val.Type()
+		for i := 0; i < t.NumField(); i++ {
+			names = append(names, t.Field(i).Name)
+			kinds = append(kinds, "value")
+			types = append(types, t.Field(i).Type.String())
+		}
+	}
+	val := reflect.Value(v)
+	for i := 0; i < val.NumMethod(); i++ {
+		names = append(names, lower(val.Type().Method(i).Name))
+		kinds = append(kinds, "function")
+		types = append(types, val.Method(i).Type().String())
+	}
+	return apl.DescribeTable(names, kinds, types)
+}
+
 // Keys returns the field names, if the value is a struct.
 // It does not return the method names.
 // It returns nil, if the Value is not a struct.
 func (v Value) Keys() []apl.Value {
-	val := reflect.Value(v)
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
-	}
+	val := indirect(reflect.Value(v))
 	if val.Kind() != reflect.Struct {
 		return nil
 	}
@@ -79,6 +193,7 @@ func (v Value) Methods() []string {
 }
 
 // Field returns the value of a field or a method with the given name.
+// A channel field returns its type, see Receive.
 func (v Value) At(key apl.Value) apl.Value {
 	name, ok := key.(apl.String)
 	if ok == false {
@@ -91,9 +206,7 @@ func (v Value) At(key apl.Value) apl.Value {
 	if m != zero {
 		return Function{Name: Name, Fn: m}
 	}
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
-	}
+	val = indirect(val)
 	if val.Kind() != reflect.Struct {
 		return nil
 	}
@@ -101,6 +214,9 @@ func (v Value) At(key apl.Value) apl.Value {
 	if sf == zero {
 		return nil
 	}
+	if sf.Kind() == reflect.Chan {
+		return apl.String(sf.Type().String())
+	}
 	rv, err := Convert(sf)
 	if err != nil {
 		return nil
@@ -113,7 +229,7 @@ func (v Value) Set(key apl.Value, fv apl.Value) error {
 	if ok == false {
 		return fmt.Errorf("key must be a string")
 	}
-	val := reflect.Value(v).Elem()
+	val := indirect(reflect.Value(v))
 	if val.Kind() != reflect.Struct {
 		return fmt.Errorf("not a struct: cannot set field")
 	}
@@ -122,6 +238,9 @@ func (v Value) Set(key apl.Value, fv apl.Value) error {
 	if sf == zero {
 		return fmt.Errorf("%v: field does not exist: %s", val.Type(), field)
 	}
+	if sf.Kind() == reflect.Chan {
+		return send(sf, fv)
+	}
 	sv, err := export(fv, sf.Type())
 	if err != nil {
 		return err
@@ -130,6 +249,86 @@ func (v Value) Set(key apl.Value, fv apl.Value) error {
 	return nil
 }
 
+// indirect follows pointers to the final value.
+// It returns the zero Value for a nil pointer.
+func indirect(val reflect.Value) reflect.Value {
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return reflect.Value{}
+		}
+		val = val.Elem()
+	}
+	return val
+}
+
+// chanField returns the field value, if it is a channel.
+func (v Value) chanField(key apl.Value) (reflect.Value, bool) {
+	var zero reflect.Value
+	name, ok := key.(apl.String)
+	if ok == false {
+		return zero, false
+	}
+	val := indirect(reflect.Value(v))
+	if val.Kind() != reflect.Struct {
+		return zero, false
+	}
+	sf := val.FieldByName(upper(string(name)))
+	if sf == zero || sf.Kind() != reflect.Chan {
+		return zero, false
+	}
+	return sf, true
+}
+
+// Receive reads a value from a channel field, see apl.Receiver.
+// This blocks until a value is available.
+// A closed channel returns an empty array.
+func (v Value) Receive(key apl.Value) (apl.Value, bool) {
+	c, ok := v.chanField(key)
+	if ok == false {
+		return nil, false
+	}
+	return receive(c), true
+}
+
+// receive reads a value from a go channel.
+// It returns an empty array, if the channel is closed.
+// A nil channel would block forever and returns nil.
+func receive(c reflect.Value) apl.Value {
+	if c.IsNil() || c.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil
+	}
+	x, ok := c.Recv()
+	if ok == false {
+		return apl.EmptyArray{}
+	}
+	v, err := Convert(x)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// send sends a value over a go channel.
+// It blocks until the value is received or the channel buffer has space.
+func send(c reflect.Value, v apl.Value) (err error) {
+	if c.IsNil() {
+		return fmt.Errorf("send to nil channel")
+	} else if c.Type().ChanDir()&reflect.SendDir == 0 {
+		return fmt.Errorf("cannot send to a receive-only channel")
+	}
+	x, err := export(v, c.Type().Elem())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	c.Send(x)
+	return nil
+}
+
 type create struct {
 	reflect.Type
 }
@@ -141,6 +340,11 @@ func (t create) Copy() apl.Value {
 	return t
 }
 
+// GoType returns the signature of the constructor.
+func (t create) GoType() string {
+	return "func() " + reflect.PtrTo(t.Type).String()
+}
+
 func (t create) Call(a *apl.Apl, L, R apl.Value) (apl.Value, error) {
 	v := reflect.New(t.Type)
 	return Value(v), nil
@@ -0,0 +1,37 @@
+package xgo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ktye/iv/apl"
+)
+
+type cycle struct {
+	V    int
+	Next *cycle
+	M    graph
+}
+
+type graph map[string]graph
+
+func TestDeepCopyCycle(t *testing.T) {
+	c := &cycle{V: 1, M: make(graph)}
+	c.Next = c
+	c.M["m"] = c.M
+
+	d := reflect.Value(apl.DeepCopy(Value(reflect.ValueOf(c))).(Value)).Interface().(*cycle)
+	if d == c {
+		t.Fatal("pointer is not copied")
+	}
+	if d.Next != d {
+		t.Fatal("cycle is not preserved")
+	}
+	d.V = 2
+	if c.V != 1 {
+		t.Fatal("copy shares the value")
+	}
+	if p := reflect.ValueOf(d.M).Pointer(); p == reflect.ValueOf(c.M).Pointer() || reflect.ValueOf(d.M["m"]).Pointer() != p {
+		t.Fatal("map cycle is not preserved")
+	}
+}
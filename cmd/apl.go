@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 
 	"github.com/ktye/iv/apl"
 )
@@ -33,6 +34,19 @@ func Apl(a *apl.Apl, stdin io.Reader, args []string) error {
 	}
 
 	// Run interactively.
+	// Ctrl-C interrupts a running evaluation, but does not exit.
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	defer func() {
+		signal.Stop(sig)
+		close(sig)
+	}()
+	go func() {
+		for range sig {
+			a.Interrupt()
+		}
+	}()
+
 	scanner := bufio.NewScanner(stdin)
 	for scanner.Scan() {
 		s := scanner.Text()
@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/ktye/iv/apl"
+	"github.com/ktye/iv/apl/big"
 	"github.com/ktye/iv/apl/numbers"
 	"github.com/ktye/iv/apl/operators"
 	"github.com/ktye/iv/apl/primitives"
@@ -30,5 +31,6 @@ func newApl() *apl.Apl {
 	numbers.Register(a)
 	primitives.Register(a)
 	operators.Register(a)
+	big.Register(a, "")
 	return a
 }